@@ -0,0 +1,92 @@
+package adapter
+
+import (
+	"slices"
+
+	"github.com/yuppyweb/cakelog"
+)
+
+// Is the key under which earlier versions of the adapters nested the arguments, to be set as ArgsKey
+// to keep entries compatible with queries and dashboards built on that layout.
+const LegacyArgsKey = "context"
+
+// Helper function to resolve the message of an entry. Without a message the error text is used,
+// and without either the message is empty. The error itself is written to the native error slot of the backend.
+func entryMessage(msg string, err error) string {
+	if msg != "" || err == nil {
+		return msg
+	}
+
+	return err.Error()
+}
+
+// Helper function to prepare an error for the native error slot of a backend.
+// A typed nil pointer, whose Error method would usually panic, is treated like a nil error,
+// so logging it writes the entry without an error instead of crashing the caller.
+func entryError(err error) error {
+	if cakelog.IsNilError(err) {
+		return nil
+	}
+
+	return err
+}
+
+// Helper function to split the fields bound with With into the fields without lazy values,
+// which the backends encode once, and the fields holding lazy values, which are appended to the lazy fields
+// already bound and written with every entry after being renewed with cakelog.RenewLazy,
+// so they are computed only for entries that are written, once per entry.
+func bindFields(bound []cakelog.Field, fields []cakelog.Field) ([]cakelog.Field, []cakelog.Field) {
+	static := make([]cakelog.Field, 0, len(fields))
+	lazy := slices.Clip(bound)
+
+	for idx, field := range fields {
+		if cakelog.HasLazy(fields[idx : idx+1]) {
+			lazy = append(lazy, field)
+		} else {
+			static = append(static, field)
+		}
+	}
+
+	return static, lazy
+}
+
+// Is a level of the fields bound to a slog or zap logger from the first lazy value on, which the backend cannot hold,
+// since it encodes bound fields right away and nests them in the groups opened so far.
+type boundGroup struct {
+	// The name of the group opened with WithGroup, empty for the level at which the first lazy value was bound.
+	name string
+
+	// The fields bound at this level, written before the groups opened after them.
+	fields []cakelog.Field
+}
+
+// Helper function to bind fields to a logger whose backend nests bound fields in groups, such as slog and zap.
+// Until a lazy value is bound, the fields are returned to be bound by the backend, with those holding lazy values
+// starting the bound groups instead. From then on, the fields are added to the innermost bound group,
+// so they keep their position relative to the groups opened later.
+func bindGroupFields(groups []boundGroup, fields []cakelog.Field) ([]cakelog.Field, []boundGroup) {
+	if len(groups) == 0 {
+		static, lazy := bindFields(nil, fields)
+		if len(lazy) == 0 {
+			return static, nil
+		}
+
+		return static, []boundGroup{{fields: lazy}}
+	}
+
+	groups = slices.Clone(groups)
+	last := &groups[len(groups)-1]
+	last.fields = append(slices.Clip(last.fields), fields...)
+
+	return nil, groups
+}
+
+// Helper function to open a group on a logger with bound groups, or to return nil if it has none,
+// in which case the backend opens the group itself.
+func bindGroup(groups []boundGroup, name string) []boundGroup {
+	if len(groups) == 0 {
+		return nil
+	}
+
+	return append(slices.Clip(groups), boundGroup{name: name})
+}
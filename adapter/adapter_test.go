@@ -0,0 +1,221 @@
+package adapter_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/sirupsen/logrus"
+	"github.com/yuppyweb/cakelog"
+	"github.com/yuppyweb/cakelog/adapter"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+type pointerError struct {
+	msg string
+}
+
+func (e *pointerError) Error() string {
+	return e.msg
+}
+
+type jsonAdapter struct {
+	logger interface {
+		cakelog.Logger
+		cakelog.LevelLogger
+	}
+	messageKey string
+}
+
+func newJSONAdapters(buf *bytes.Buffer) map[string]jsonAdapter {
+	zapCore := zapcore.NewCore(
+		zapcore.NewJSONEncoder(zapcore.EncoderConfig{MessageKey: "msg"}),
+		zapcore.AddSync(buf),
+		zap.DebugLevel,
+	)
+	zerologLogger := zerolog.New(buf)
+	logrusLogger := logrus.New()
+	logrusLogger.SetOutput(buf)
+	logrusLogger.SetFormatter(&logrus.JSONFormatter{DisableTimestamp: true})
+
+	return map[string]jsonAdapter{
+		"slog":    {adapter.NewSlogLogger(slog.New(slog.NewJSONHandler(buf, nil))), slog.MessageKey},
+		"zap":     {adapter.NewZapLogger(zap.New(zapCore)), "msg"},
+		"zerolog": {adapter.NewZerologLogger(&zerologLogger), zerolog.MessageFieldName},
+		"logrus":  {adapter.NewLogrusLogger(logrusLogger), logrus.FieldKeyMsg},
+	}
+}
+
+func TestAdapters_ErrorField(t *testing.T) {
+	t.Parallel()
+
+	expectedErr := errors.New("card declined")
+
+	tests := []struct {
+		name            string
+		msg             string
+		err             error
+		expectedMessage string
+		expectedError   any
+	}{
+		{"error only", "", expectedErr, "card declined", "card declined"},
+		{"message and error", "payment failed", expectedErr, "payment failed", "card declined"},
+		{"nil error", "payment failed", nil, "payment failed", nil},
+		{"nil error without message", "", nil, "", nil},
+		{"typed nil error", "", (*pointerError)(nil), "", nil},
+	}
+
+	for _, test := range tests {
+		buf := &bytes.Buffer{}
+
+		for name, adp := range newJSONAdapters(buf) {
+			buf.Reset()
+
+			adp.logger.Log(context.Background(), cakelog.LevelError, test.msg, test.err)
+
+			var entry map[string]any
+			if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+				t.Fatalf("%s, %s: failed to decode %q: %v", name, test.name, buf.String(), err)
+			}
+
+			if message, _ := entry[adp.messageKey].(string); message != test.expectedMessage {
+				t.Errorf("%s, %s: expected message %q, got %q", name, test.name, test.expectedMessage, message)
+			}
+
+			if value := entry[cakelog.ErrorKey]; value != test.expectedError {
+				t.Errorf("%s, %s: expected error %v, got %v", name, test.name, test.expectedError, value)
+			}
+		}
+	}
+}
+
+type queryError struct {
+	query string
+}
+
+func (e *queryError) Error() string {
+	return "query failed"
+}
+
+func (e *queryError) LogFields() []any {
+	return []any{"query", e.query}
+}
+
+func TestAdapters_ErrorChain(t *testing.T) {
+	t.Parallel()
+
+	err := fmt.Errorf("load user: %w", errors.Join(&queryError{query: "SELECT 1"}, errors.New("timeout")))
+	expected := []any{
+		map[string]any{"message": "load user: query failed\ntimeout", "type": "*fmt.wrapError"},
+		map[string]any{"message": "query failed\ntimeout", "type": "*errors.joinError"},
+		map[string]any{
+			"message": "query failed",
+			"type":    "*adapter_test.queryError",
+			"fields":  map[string]any{"query": "SELECT 1"},
+		},
+		map[string]any{"message": "timeout", "type": "*errors.errorString"},
+	}
+
+	buf := &bytes.Buffer{}
+
+	for name, adp := range newJSONAdapters(buf) {
+		buf.Reset()
+
+		adp.logger.Log(context.Background(), cakelog.LevelError, "", err)
+
+		var entry map[string]any
+		if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+			t.Fatalf("%s: failed to decode %q: %v", name, buf.String(), err)
+		}
+
+		if chain := entry[cakelog.ErrorChainKey]; !reflect.DeepEqual(chain, expected) {
+			t.Errorf("%s: unexpected chain:\nGot:  %v\nWant: %v", name, chain, expected)
+		}
+
+		buf.Reset()
+
+		adp.logger.Log(context.Background(), cakelog.LevelError, "", errors.New("plain"))
+
+		if bytes.Contains(buf.Bytes(), []byte(cakelog.ErrorChainKey)) {
+			t.Errorf("%s: expected no chain for a plain error, got %s", name, buf.String())
+		}
+	}
+}
+
+func TestAdapters_BoundLazyValues(t *testing.T) {
+	t.Parallel()
+
+	buf := &bytes.Buffer{}
+
+	for name, adp := range newJSONAdapters(buf) {
+		buf.Reset()
+
+		calls := 0
+		logger := cakelog.With(adp.logger, cakelog.LazyField("seq", func() any {
+			calls++
+
+			return calls
+		}), "service", "api")
+
+		if calls != 0 {
+			t.Fatalf("%s: expected the bound lazy value not to be resolved by With, got %d calls", name, calls)
+		}
+
+		logger.Debug(context.Background(), "first")
+		cakelog.Log(context.Background(), logger, cakelog.LevelTrace, "trace", nil)
+		logger.Info(context.Background(), "second")
+		logger.Warn(context.Background(), "third")
+
+		lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+
+		if len(lines) < 2 || calls != len(lines) {
+			t.Fatalf("%s: expected the lazy value to be resolved once per written entry, got %d calls for %q",
+				name, calls, buf.String())
+		}
+
+		for idx, line := range lines {
+			var entry map[string]any
+			if err := json.Unmarshal(line, &entry); err != nil {
+				t.Fatalf("%s: failed to decode %q: %v", name, line, err)
+			}
+
+			if entry["seq"] != float64(idx+1) || entry["service"] != "api" {
+				t.Errorf("%s: expected a fresh value in every entry, got %q", name, line)
+			}
+		}
+	}
+}
+
+func TestAdapters_BoundLazyValuesInGroups(t *testing.T) {
+	t.Parallel()
+
+	buf := &bytes.Buffer{}
+
+	for name, adp := range newJSONAdapters(buf) {
+		buf.Reset()
+
+		logger := cakelog.With(adp.logger, "static", 1, cakelog.LazyField("lazy", func() any { return 2 }))
+		logger = cakelog.WithGroup(logger, "req")
+		logger = cakelog.With(logger, cakelog.LazyField("inner", func() any { return 3 }), "method", "GET")
+
+		logger.Info(context.Background(), "grouped", "k", "v")
+
+		var entry map[string]any
+		if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+			t.Fatalf("%s: failed to decode %q: %v", name, buf.String(), err)
+		}
+
+		expected := map[string]any{"inner": float64(3), "method": "GET", "k": "v"}
+
+		if entry["static"] != float64(1) || entry["lazy"] != float64(2) || !reflect.DeepEqual(entry["req"], expected) {
+			t.Errorf("%s: expected the bound fields to keep their place around the group, got %s", name, buf.String())
+		}
+	}
+}
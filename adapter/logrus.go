@@ -7,45 +7,213 @@ import (
 	"github.com/yuppyweb/cakelog"
 )
 
-// Is the default key under which context arguments will be stored in logrus entries.
-const DefaultLogrusArgsKey = "context"
+// Is the key under which LogrusLogger nested the arguments before they became top-level fields.
+//
+// Deprecated: Use LegacyArgsKey.
+const DefaultLogrusArgsKey = LegacyArgsKey
 
 // Is an adapter that allows using a logrus.Logger as a cakelog.Logger.
 type LogrusLogger struct {
-	// The underlying logrus.Logger to which log messages will be forwarded.
-	logger *logrus.Logger
+	// The entry of the underlying logrus.Logger to which log messages will be forwarded.
+	// It holds the fields bound with With.
+	entry *logrus.Entry
 
-	// The key under which the context arguments will be stored in logrus entries.
+	// The key of a map under which the arguments are nested, as a compatibility mode.
+	// If empty, which is the default, the arguments are written as logrus.Fields,
+	// so formatters and hooks see every argument as a field of its own.
 	ArgsKey string
+
+	// The message of error entries logged without a message, such as with Error.
+	// If empty, which is the default, the text of the error is used as the message.
+	// In both cases the error itself is attached with logrus.Entry.WithError.
+	ErrorMessage string
+
+	// The fields bound with With that hold lazy values, which are computed for every entry that is written.
+	lazy []cakelog.Field
 }
 
-// Creates a new LogrusLogger that wraps the provided logrus.Logger.
+// Creates a new LogrusLogger that wraps the provided logrus.Logger and writes the arguments as logrus.Fields.
 func NewLogrusLogger(logger *logrus.Logger) *LogrusLogger {
 	return &LogrusLogger{
-		logger:  logger,
-		ArgsKey: DefaultLogrusArgsKey,
+		entry: logrus.NewEntry(logger),
 	}
 }
 
 // Sends a debug message to the underlying logrus.Logger with the provided context and arguments.
 func (ll *LogrusLogger) Debug(ctx context.Context, msg string, args ...any) {
-	ll.logger.WithContext(ctx).WithField(ll.ArgsKey, args).Debug(msg)
+	ll.Log(ctx, cakelog.LevelDebug, msg, nil, args...)
 }
 
 // Sends an info message to the underlying logrus.Logger with the provided context and arguments.
 func (ll *LogrusLogger) Info(ctx context.Context, msg string, args ...any) {
-	ll.logger.WithContext(ctx).WithField(ll.ArgsKey, args).Info(msg)
+	ll.Log(ctx, cakelog.LevelInfo, msg, nil, args...)
 }
 
 // Sends a warning message to the underlying logrus.Logger with the provided context and arguments.
 func (ll *LogrusLogger) Warn(ctx context.Context, msg string, args ...any) {
-	ll.logger.WithContext(ctx).WithField(ll.ArgsKey, args).Warn(msg)
+	ll.Log(ctx, cakelog.LevelWarn, msg, nil, args...)
 }
 
 // Sends an error message to the underlying logrus.Logger with the provided context, error, and arguments.
 func (ll *LogrusLogger) Error(ctx context.Context, err error, args ...any) {
-	ll.logger.WithContext(ctx).WithField(ll.ArgsKey, args).Error(err)
+	ll.Log(ctx, cakelog.LevelError, "", err, args...)
+}
+
+// Sends a message at the given level to the underlying logrus.Logger with the provided context, error, and arguments.
+// The error is attached with logrus.Entry.WithError, and ErrorMessage or its text is used if the message is empty.
+func (ll *LogrusLogger) Log(ctx context.Context, level cakelog.Level, msg string, err error, args ...any) {
+	if !ll.entry.Logger.IsLevelEnabled(LogrusLevel(level)) {
+		return
+	}
+
+	err = entryError(err)
+
+	entry := ll.entry.WithContext(ctx)
+	fields := cakelog.Normalize(args...)
+
+	if len(ll.lazy) > 0 {
+		entry = entry.WithFields(logrusFields(cakelog.RenewLazy(ll.lazy)))
+	}
+
+	if ll.ArgsKey == "" {
+		entry = entry.WithFields(logrusFields(fields))
+	} else {
+		entry = entry.WithField(ll.ArgsKey, logrusMap(fields))
+	}
+
+	if err != nil {
+		entry = entry.WithError(err)
+
+		if msg == "" {
+			msg = ll.ErrorMessage
+		}
+	}
+
+	if cakelog.HasErrorChain(err) {
+		entry = entry.WithField(cakelog.ErrorChainKey, logrusErrorChain(cakelog.ErrorChain(err)))
+	}
+
+	logrusLog(entry, LogrusLevel(level), entryMessage(msg, err))
+}
+
+// Reports whether the underlying logrus.Logger would write a message at the given level.
+func (ll *LogrusLogger) Enabled(_ context.Context, level cakelog.Level) bool {
+	return ll.entry.Logger.IsLevelEnabled(LogrusLevel(level))
+}
+
+// Returns a LogrusLogger whose entry has the given arguments bound with logrus.Entry.WithFields.
+// The bound arguments are added at the top level of every entry, also in the ArgsKey mode.
+// Arguments holding lazy values are kept apart and computed for every entry that is written.
+func (ll *LogrusLogger) With(args ...any) cakelog.Logger {
+	fields, lazy := bindFields(ll.lazy, cakelog.Normalize(args...))
+
+	return &LogrusLogger{
+		entry:        ll.entry.WithFields(logrusFields(fields)),
+		ArgsKey:      ll.ArgsKey,
+		ErrorMessage: ll.ErrorMessage,
+		lazy:         lazy,
+	}
+}
+
+// Helper function to convert fields to logrus.Fields, with nested fields becoming nested maps and lazy values resolved.
+// Top-level errors are kept, since logrus formatters write them as their text and hooks may inspect them.
+func logrusFields(fields []cakelog.Field) logrus.Fields {
+	values := make(logrus.Fields, len(fields))
+
+	for _, field := range fields {
+		switch value := cakelog.Resolve(field.Value).(type) {
+		case []cakelog.Field:
+			values[field.Key] = logrusMap(value)
+		default:
+			values[field.Key] = value
+		}
+	}
+
+	return values
+}
+
+// Helper function to convert fields to a map, with nested fields becoming nested maps,
+// lazy values resolved and errors replaced by their text, since logrus formatters only handle top-level errors.
+func logrusMap(fields []cakelog.Field) map[string]any {
+	values := make(map[string]any, len(fields))
+
+	for _, field := range fields {
+		switch value := cakelog.Resolve(field.Value).(type) {
+		case []cakelog.Field:
+			values[field.Key] = logrusMap(value)
+		case error:
+			values[field.Key] = value.Error()
+		default:
+			values[field.Key] = value
+		}
+	}
+
+	return values
+}
+
+// Helper function to convert an error chain to a list of maps with the message, type and fields of every error.
+func logrusErrorChain(chain []cakelog.ErrorDetail) []map[string]any {
+	details := make([]map[string]any, 0, len(chain))
+
+	for _, detail := range chain {
+		values := map[string]any{"message": detail.Message, "type": detail.Type}
+
+		if len(detail.Fields) > 0 {
+			values["fields"] = logrusMap(detail.Fields)
+		}
+
+		details = append(details, values)
+	}
+
+	return details
 }
 
-// Ensures that LogrusLogger implements the cakelog.Logger interface.
-var _ cakelog.Logger = (*LogrusLogger)(nil)
+// Helper function to write an entry at the given level,
+// recovering from the panic that logrus raises after writing a panic level entry.
+func logrusLog(entry *logrus.Entry, level logrus.Level, msg string) {
+	if level == logrus.PanicLevel {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				if _, ok := recovered.(*logrus.Entry); !ok {
+					panic(recovered)
+				}
+			}
+		}()
+	}
+
+	entry.Log(level, msg)
+}
+
+// Maps a cakelog.Level to the closest logrus level, as LogrusLogger does for every message.
+func LogrusLevel(level cakelog.Level) logrus.Level {
+	switch {
+	case level < cakelog.LevelDebug:
+		return logrus.TraceLevel
+	case level < cakelog.LevelInfo:
+		return logrus.DebugLevel
+	case level < cakelog.LevelWarn:
+		return logrus.InfoLevel
+	case level < cakelog.LevelError:
+		return logrus.WarnLevel
+	case level < cakelog.LevelFatal:
+		return logrus.ErrorLevel
+	case level < cakelog.LevelPanic:
+		return logrus.FatalLevel
+	default:
+		return logrus.PanicLevel
+	}
+}
+
+var (
+	// Ensures that LogrusLogger implements the cakelog.Logger interface.
+	_ cakelog.Logger = (*LogrusLogger)(nil)
+
+	// Ensures that LogrusLogger implements the cakelog.LevelLogger interface.
+	_ cakelog.LevelLogger = (*LogrusLogger)(nil)
+
+	// Ensures that LogrusLogger implements the cakelog.WithLogger interface.
+	_ cakelog.WithLogger = (*LogrusLogger)(nil)
+
+	// Ensures that LogrusLogger implements the cakelog.Enabler interface.
+	_ cakelog.Enabler = (*LogrusLogger)(nil)
+)
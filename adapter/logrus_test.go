@@ -5,9 +5,11 @@ import (
 	"context"
 	"errors"
 	"io"
+	"reflect"
 	"testing"
 
 	"github.com/sirupsen/logrus"
+	"github.com/yuppyweb/cakelog"
 	"github.com/yuppyweb/cakelog/adapter"
 )
 
@@ -25,6 +27,7 @@ func TestLogrusLogger_DebugWithDefaultArgsKey(t *testing.T) {
 	})
 
 	logger := adapter.NewLogrusLogger(log)
+	logger.ArgsKey = adapter.LegacyArgsKey
 
 	logger.Debug(context.Background(), "debug message", "debug", 42)
 
@@ -33,7 +36,7 @@ func TestLogrusLogger_DebugWithDefaultArgsKey(t *testing.T) {
 		t.Fatalf("failed to read log output: %v", err)
 	}
 
-	expected := `level=debug msg="debug message" context="[debug 42]"` + "\n"
+	expected := `level=debug msg="debug message" context="map[debug:42]"` + "\n"
 
 	if string(output) != expected {
 		t.Errorf("unexpected log output:\nGot:  %s\nWant: %s", string(output), expected)
@@ -54,6 +57,7 @@ func TestLogrusLogger_InfoWithDefaultArgsKey(t *testing.T) {
 	})
 
 	logger := adapter.NewLogrusLogger(log)
+	logger.ArgsKey = adapter.LegacyArgsKey
 
 	logger.Info(context.Background(), "info message", "info", 75)
 
@@ -62,7 +66,7 @@ func TestLogrusLogger_InfoWithDefaultArgsKey(t *testing.T) {
 		t.Fatalf("failed to read log output: %v", err)
 	}
 
-	expected := `level=info msg="info message" context="[info 75]"` + "\n"
+	expected := `level=info msg="info message" context="map[info:75]"` + "\n"
 
 	if string(output) != expected {
 		t.Errorf("unexpected log output:\nGot:  %s\nWant: %s", string(output), expected)
@@ -83,6 +87,7 @@ func TestLogrusLogger_WarnWithDefaultArgsKey(t *testing.T) {
 	})
 
 	logger := adapter.NewLogrusLogger(log)
+	logger.ArgsKey = adapter.LegacyArgsKey
 
 	logger.Warn(context.Background(), "warn message", "warn", 85)
 
@@ -91,7 +96,7 @@ func TestLogrusLogger_WarnWithDefaultArgsKey(t *testing.T) {
 		t.Fatalf("failed to read log output: %v", err)
 	}
 
-	expected := `level=warning msg="warn message" context="[warn 85]"` + "\n"
+	expected := `level=warning msg="warn message" context="map[warn:85]"` + "\n"
 
 	if string(output) != expected {
 		t.Errorf("unexpected log output:\nGot:  %s\nWant: %s", string(output), expected)
@@ -112,6 +117,7 @@ func TestLogrusLogger_ErrorWithDefaultArgsKey(t *testing.T) {
 	})
 
 	logger := adapter.NewLogrusLogger(log)
+	logger.ArgsKey = adapter.LegacyArgsKey
 
 	expectedErr := errors.New("error message")
 	logger.Error(context.Background(), expectedErr, "error", 90)
@@ -121,7 +127,7 @@ func TestLogrusLogger_ErrorWithDefaultArgsKey(t *testing.T) {
 		t.Fatalf("failed to read log output: %v", err)
 	}
 
-	expected := `level=error msg="error message" context="[error 90]"` + "\n"
+	expected := `level=error msg="error message" context="map[error:90]" error="error message"` + "\n"
 
 	if string(output) != expected {
 		t.Errorf("unexpected log output:\nGot:  %s\nWant: %s", string(output), expected)
@@ -151,7 +157,7 @@ func TestLogrusLogger_DebugWithCustomArgsKey(t *testing.T) {
 		t.Fatalf("failed to read log output: %v", err)
 	}
 
-	expected := `level=debug msg="debug message" custom_args1="[debug 42]"` + "\n"
+	expected := `level=debug msg="debug message" custom_args1="map[debug:42]"` + "\n"
 
 	if string(output) != expected {
 		t.Errorf("unexpected log output:\nGot:  %s\nWant: %s", string(output), expected)
@@ -181,7 +187,7 @@ func TestLogrusLogger_InfoWithCustomArgsKey(t *testing.T) {
 		t.Fatalf("failed to read log output: %v", err)
 	}
 
-	expected := `level=info msg="info message" custom_args2="[info 75]"` + "\n"
+	expected := `level=info msg="info message" custom_args2="map[info:75]"` + "\n"
 
 	if string(output) != expected {
 		t.Errorf("unexpected log output:\nGot:  %s\nWant: %s", string(output), expected)
@@ -211,7 +217,7 @@ func TestLogrusLogger_WarnWithCustomArgsKey(t *testing.T) {
 		t.Fatalf("failed to read log output: %v", err)
 	}
 
-	expected := `level=warning msg="warn message" custom_args3="[warn 85]"` + "\n"
+	expected := `level=warning msg="warn message" custom_args3="map[warn:85]"` + "\n"
 
 	if string(output) != expected {
 		t.Errorf("unexpected log output:\nGot:  %s\nWant: %s", string(output), expected)
@@ -242,9 +248,251 @@ func TestLogrusLogger_ErrorWithCustomArgsKey(t *testing.T) {
 		t.Fatalf("failed to read log output: %v", err)
 	}
 
-	expected := `level=error msg="error message" custom_args4="[error 90]"` + "\n"
+	expected := `level=error msg="error message" custom_args4="map[error:90]" error="error message"` + "\n"
 
 	if string(output) != expected {
 		t.Errorf("unexpected log output:\nGot:  %s\nWant: %s", string(output), expected)
 	}
 }
+
+func TestLogrusLogger_LogLevels(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		level    cakelog.Level
+		expected string
+	}{
+		{cakelog.LevelTrace, "trace"},
+		{cakelog.LevelDebug, "debug"},
+		{cakelog.LevelInfo - 1, "debug"},
+		{cakelog.LevelInfo, "info"},
+		{cakelog.LevelWarn, "warning"},
+		{cakelog.LevelError, "error"},
+		{cakelog.LevelError + 1, "error"},
+		{cakelog.LevelFatal, "fatal"},
+		{cakelog.LevelPanic, "panic"},
+	}
+
+	for _, test := range tests {
+		buf := &bytes.Buffer{}
+		log := logrus.New()
+
+		log.SetOutput(buf)
+		log.Level = logrus.TraceLevel
+		log.SetFormatter(&logrus.TextFormatter{
+			DisableTimestamp: true,
+			DisableColors:    true,
+		})
+
+		logger := adapter.NewLogrusLogger(log)
+
+		logger.Log(context.Background(), test.level, "log message", nil)
+
+		expected := `level=` + test.expected + ` msg="log message"` + "\n"
+
+		if buf.String() != expected {
+			t.Errorf("unexpected log output:\nGot:  %s\nWant: %s", buf.String(), expected)
+		}
+	}
+}
+
+func TestLogrusLogger_NormalizedArgs(t *testing.T) {
+	t.Parallel()
+
+	buf := &bytes.Buffer{}
+	log := logrus.New()
+
+	log.SetOutput(buf)
+	log.SetFormatter(&logrus.JSONFormatter{
+		DisableTimestamp: true,
+	})
+
+	logger := adapter.NewLogrusLogger(log)
+
+	logger.Info(
+		context.Background(),
+		"info message",
+		"user", 42,
+		cakelog.NewGroup("req", "method", "GET"),
+		map[string]any{"cause": errors.New("timeout")},
+	)
+
+	expected := `{"cause":"timeout","level":"info","msg":"info message","req":{"method":"GET"},"user":42}` + "\n"
+
+	if buf.String() != expected {
+		t.Errorf("unexpected log output:\nGot:  %s\nWant: %s", buf.String(), expected)
+	}
+}
+
+func TestLogrusLogger_With(t *testing.T) {
+	t.Parallel()
+
+	buf := &bytes.Buffer{}
+	log := logrus.New()
+
+	log.SetOutput(buf)
+	log.SetFormatter(&logrus.TextFormatter{
+		DisableTimestamp: true,
+		DisableColors:    true,
+	})
+
+	logger := cakelog.With(adapter.NewLogrusLogger(log), "service", "api")
+
+	if _, ok := logger.(*adapter.LogrusLogger); !ok {
+		t.Fatalf("expected native *adapter.LogrusLogger, got %T", logger)
+	}
+
+	logger.Info(context.Background(), "info message", "user", 42)
+
+	expected := `level=info msg="info message" service=api user=42` + "\n"
+
+	if buf.String() != expected {
+		t.Errorf("unexpected log output:\nGot:  %s\nWant: %s", buf.String(), expected)
+	}
+}
+
+func TestLogrusLogger_Enabled(t *testing.T) {
+	t.Parallel()
+
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+	log.Level = logrus.InfoLevel
+
+	logger := adapter.NewLogrusLogger(log)
+
+	if logger.Enabled(context.Background(), cakelog.LevelDebug) {
+		t.Error("expected debug to be disabled")
+	}
+
+	if !logger.Enabled(context.Background(), cakelog.LevelInfo) {
+		t.Error("expected info to be enabled")
+	}
+}
+
+func TestLogrusLogger_LazyArgs(t *testing.T) {
+	t.Parallel()
+
+	buf := &bytes.Buffer{}
+	log := logrus.New()
+
+	log.SetOutput(buf)
+	log.SetFormatter(&logrus.TextFormatter{
+		DisableTimestamp: true,
+		DisableColors:    true,
+	})
+
+	logger := adapter.NewLogrusLogger(log)
+	calls := 0
+	body := cakelog.LazyField("body", func() any {
+		calls++
+
+		return "payload"
+	})
+
+	logger.Debug(context.Background(), "debug message", body)
+
+	if calls != 0 {
+		t.Fatalf("expected the lazy value not to be resolved for a disabled level, got %d calls", calls)
+	}
+
+	logger.Info(context.Background(), "info message", body)
+
+	expected := `level=info msg="info message" body=payload` + "\n"
+
+	if buf.String() != expected {
+		t.Errorf("unexpected log output:\nGot:  %s\nWant: %s", buf.String(), expected)
+	}
+
+	if calls != 1 {
+		t.Errorf("expected the lazy value to be resolved once, got %d calls", calls)
+	}
+}
+
+type mockLogrusHook struct {
+	entries []*logrus.Entry
+}
+
+func (h *mockLogrusHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (h *mockLogrusHook) Fire(entry *logrus.Entry) error {
+	h.entries = append(h.entries, entry)
+
+	return nil
+}
+
+func TestLogrusLogger_Fields(t *testing.T) {
+	t.Parallel()
+
+	hook := new(mockLogrusHook)
+	log := logrus.New()
+
+	log.SetOutput(io.Discard)
+	log.AddHook(hook)
+
+	logger := adapter.NewLogrusLogger(log)
+	cause := errors.New("timeout")
+	expectedErr := errors.New("query failed")
+
+	logger.Error(
+		context.Background(),
+		expectedErr,
+		"user", 42,
+		"cause", cause,
+		cakelog.NewGroup("req", "method", "GET"),
+	)
+
+	if len(hook.entries) != 1 {
+		t.Fatalf("expected 1 entry, got %d", len(hook.entries))
+	}
+
+	entry := hook.entries[0]
+	expected := logrus.Fields{
+		"user":          42,
+		"cause":         cause,
+		"req":           map[string]any{"method": "GET"},
+		logrus.ErrorKey: expectedErr,
+	}
+
+	if !reflect.DeepEqual(entry.Data, expected) {
+		t.Errorf("unexpected fields:\nGot:  %v\nWant: %v", entry.Data, expected)
+	}
+
+	if entry.Message != "query failed" {
+		t.Errorf("expected the error text as the message, got %q", entry.Message)
+	}
+}
+
+func TestLogrusLogger_ErrorMessage(t *testing.T) {
+	t.Parallel()
+
+	buf := &bytes.Buffer{}
+	log := logrus.New()
+
+	log.SetOutput(buf)
+	log.SetFormatter(&logrus.TextFormatter{
+		DisableTimestamp: true,
+		DisableColors:    true,
+	})
+
+	logger := adapter.NewLogrusLogger(log)
+	logger.ErrorMessage = "request failed"
+	expectedErr := errors.New("connection refused")
+
+	logger.Error(context.Background(), expectedErr, "attempt", 3)
+	cakelog.Log(
+		context.Background(),
+		cakelog.With(logger, "service", "api"),
+		cakelog.LevelError,
+		"dial failed",
+		expectedErr,
+	)
+
+	expected := `level=error msg="request failed" attempt=3 error="connection refused"` + "\n" +
+		`level=error msg="dial failed" error="connection refused" service=api` + "\n"
+
+	if buf.String() != expected {
+		t.Errorf("unexpected log output:\nGot:  %s\nWant: %s", buf.String(), expected)
+	}
+}
@@ -3,49 +3,290 @@ package adapter
 import (
 	"context"
 	"log/slog"
+	"reflect"
+	"runtime"
+	"time"
 
 	"github.com/yuppyweb/cakelog"
 )
 
-// Is the default key under which context arguments will be stored in slog entries.
-const DefaultSlogArgsKey = "context"
+// Is the key under which SlogLogger nested the arguments before they became top-level attributes.
+//
+// Deprecated: Use LegacyArgsKey.
+const DefaultSlogArgsKey = LegacyArgsKey
+
+const (
+	// Is the custom slog level of cakelog.LevelTrace messages, which slog shows as "DEBUG-4" by default.
+	SlogLevelTrace = slog.Level(cakelog.LevelTrace)
+
+	// Is the custom slog level of cakelog.LevelFatal messages, which slog shows as "ERROR+4" by default.
+	SlogLevelFatal = slog.Level(cakelog.LevelFatal)
+
+	// Is the custom slog level of cakelog.LevelPanic messages, which slog shows as "ERROR+8" by default.
+	SlogLevelPanic = slog.Level(cakelog.LevelPanic)
+)
 
 // Is an adapter that allows using a slog.Logger as a cakelog.Logger.
 type SlogLogger struct {
 	// The underlying slog.Logger to which log messages will be forwarded.
 	Logger *slog.Logger
 
-	// The key under which the context arguments will be stored in slog entries.
+	// The key of a group under which the arguments are nested, as a compatibility mode.
+	// If empty, which is the default, the arguments are written as top-level attributes.
 	ArgsKey string
+
+	// The fields and groups bound from the first lazy value on, which are added to every entry that is written,
+	// so the lazy values are computed for it.
+	groups []boundGroup
 }
 
-// Creates a new SlogLogger that wraps the provided slog.Logger.
+// Creates a new SlogLogger that wraps the provided slog.Logger and writes the arguments as top-level attributes.
 func NewSlogLogger(logger *slog.Logger) *SlogLogger {
 	return &SlogLogger{
-		Logger:  logger,
-		ArgsKey: DefaultSlogArgsKey,
+		Logger: logger,
 	}
 }
 
 // Sends a debug message to the underlying slog.Logger with the provided context and arguments.
 func (sl *SlogLogger) Debug(ctx context.Context, msg string, args ...any) {
-	sl.Logger.DebugContext(ctx, msg, slog.Any(sl.ArgsKey, args))
+	sl.log(ctx, cakelog.LevelDebug, msg, nil, args)
 }
 
 // Sends an info message to the underlying slog.Logger with the provided context and arguments.
 func (sl *SlogLogger) Info(ctx context.Context, msg string, args ...any) {
-	sl.Logger.InfoContext(ctx, msg, slog.Any(sl.ArgsKey, args))
+	sl.log(ctx, cakelog.LevelInfo, msg, nil, args)
 }
 
 // Sends a warning message to the underlying slog.Logger with the provided context and arguments.
 func (sl *SlogLogger) Warn(ctx context.Context, msg string, args ...any) {
-	sl.Logger.WarnContext(ctx, msg, slog.Any(sl.ArgsKey, args))
+	sl.log(ctx, cakelog.LevelWarn, msg, nil, args)
 }
 
 // Sends an error message to the underlying slog.Logger with the provided context, error, and arguments.
 func (sl *SlogLogger) Error(ctx context.Context, err error, args ...any) {
-	sl.Logger.ErrorContext(ctx, err.Error(), slog.Any(sl.ArgsKey, args))
+	sl.log(ctx, cakelog.LevelError, "", err, args)
+}
+
+// Sends a message at the given level to the underlying slog.Logger with the provided context, error, and arguments.
+// The error is added under cakelog.ErrorKey, and its text is used if the message is empty.
+func (sl *SlogLogger) Log(ctx context.Context, level cakelog.Level, msg string, err error, args ...any) {
+	sl.log(ctx, level, msg, err, args)
+}
+
+// Sends an existing record to the handler of the underlying slog.Logger,
+// keeping its time and program counter, so the source of the entry is the original call site.
+func (sl *SlogLogger) Handle(record cakelog.Record) {
+	ctx := record.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	handler := sl.Logger.Handler()
+	if !handler.Enabled(ctx, slog.Level(record.Level)) {
+		return
+	}
+
+	err := entryError(record.Err)
+
+	entry := slog.NewRecord(record.Time, slog.Level(record.Level), entryMessage(record.Message, err), record.PC)
+	entry.AddAttrs(sl.attrs(err, record.Attrs)...)
+
+	_ = handler.Handle(ctx, entry)
 }
 
-// Ensures that SlogLogger implements the cakelog.Logger interface.
-var _ cakelog.Logger = (*SlogLogger)(nil)
+// Reports whether the underlying slog.Logger would write a message at the given level.
+func (sl *SlogLogger) Enabled(ctx context.Context, level cakelog.Level) bool {
+	return sl.Logger.Enabled(ctx, slog.Level(level))
+}
+
+// Returns a SlogLogger whose underlying slog.Logger has the given arguments bound with slog.Logger.With.
+// The bound arguments are encoded once and added at the top level of every entry, also in the ArgsKey mode.
+// Handlers may resolve bound values right away, so from the first argument holding a lazy value on,
+// the arguments and groups are kept apart instead and added to every entry that is written, in the same place.
+func (sl *SlogLogger) With(args ...any) cakelog.Logger {
+	fields, groups := bindGroupFields(sl.groups, cakelog.Normalize(args...))
+
+	logger := sl.Logger
+	if len(fields) > 0 {
+		logger = slog.New(logger.Handler().WithAttrs(slogAttrs(fields)))
+	}
+
+	return &SlogLogger{
+		Logger:  logger,
+		ArgsKey: sl.ArgsKey,
+		groups:  groups,
+	}
+}
+
+// Returns a SlogLogger whose underlying slog.Logger nests all further attributes in a group
+// with slog.Logger.WithGroup, or that opens the group itself once lazy values are bound.
+func (sl *SlogLogger) WithGroup(name string) cakelog.Logger {
+	if name == "" {
+		return sl
+	}
+
+	groups := bindGroup(sl.groups, name)
+	if groups == nil {
+		return &SlogLogger{
+			Logger:  sl.Logger.WithGroup(name),
+			ArgsKey: sl.ArgsKey,
+		}
+	}
+
+	return &SlogLogger{
+		Logger:  sl.Logger,
+		ArgsKey: sl.ArgsKey,
+		groups:  groups,
+	}
+}
+
+// Helper method to build the attributes of an entry: the error and its chain, if any, followed by the fields,
+// either at the top level or in the ArgsKey group, all nested in the bound groups after their fields.
+// The chain is a list of cakelog.ErrorDetail, which slog.JSONHandler encodes as objects.
+func (sl *SlogLogger) attrs(err error, fields []cakelog.Field) []slog.Attr {
+	attrs := make([]slog.Attr, 0, len(fields)+2)
+
+	if err != nil {
+		attrs = append(attrs, slog.Any(cakelog.ErrorKey, err))
+
+		if cakelog.HasErrorChain(err) {
+			attrs = append(attrs, slog.Any(cakelog.ErrorChainKey, cakelog.ErrorChain(err)))
+		}
+	}
+
+	if sl.ArgsKey == "" {
+		attrs = append(attrs, slogAttrs(fields)...)
+	} else {
+		attrs = append(attrs, slog.Attr{Key: sl.ArgsKey, Value: slog.GroupValue(slogAttrs(fields)...)})
+	}
+
+	for idx := len(sl.groups) - 1; idx >= 0; idx-- {
+		group := sl.groups[idx]
+		attrs = append(slogAttrs(cakelog.RenewLazy(group.fields)), attrs...)
+
+		if idx > 0 {
+			attrs = []slog.Attr{{Key: group.name, Value: slog.GroupValue(attrs...)}}
+		}
+	}
+
+	return attrs
+}
+
+// Helper method to send a record with the program counter of the caller of the logging method
+// to the handler of the underlying slog.Logger, so the source of the entry is the call site instead of this adapter.
+func (sl *SlogLogger) log(ctx context.Context, level cakelog.Level, msg string, err error, args []any) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	handler := sl.Logger.Handler()
+	if !handler.Enabled(ctx, slog.Level(level)) {
+		return
+	}
+
+	var pcs [1]uintptr
+
+	// Skips runtime.Callers, this method and the logging method.
+	runtime.Callers(3, pcs[:])
+
+	err = entryError(err)
+
+	entry := slog.NewRecord(time.Now(), slog.Level(level), entryMessage(msg, err), pcs[0])
+	entry.AddAttrs(sl.attrs(err, cakelog.Normalize(args...))...)
+
+	_ = handler.Handle(ctx, entry)
+}
+
+// Can be used as slog.HandlerOptions.ReplaceAttr to show SlogLevelTrace, SlogLevelFatal and SlogLevelPanic
+// as "TRACE", "FATAL" and "PANIC".
+// Other attributes are returned unchanged.
+func SlogReplaceLevel(groups []string, attr slog.Attr) slog.Attr {
+	if len(groups) > 0 || attr.Key != slog.LevelKey {
+		return attr
+	}
+
+	level, ok := attr.Value.Any().(slog.Level)
+	if !ok {
+		return attr
+	}
+
+	switch level {
+	case SlogLevelTrace:
+		attr.Value = slog.StringValue("TRACE")
+	case SlogLevelFatal:
+		attr.Value = slog.StringValue("FATAL")
+	case SlogLevelPanic:
+		attr.Value = slog.StringValue("PANIC")
+	}
+
+	return attr
+}
+
+// Returns a slog.Leveler that follows the provided cakelog.Leveler, such as a cakelog.LevelVar,
+// to be used as slog.HandlerOptions.Level, so the level of the handler can be changed at runtime.
+func SlogLeveler(leveler cakelog.Leveler) slog.Leveler {
+	return slogLeveler{leveler: leveler}
+}
+
+// Is a slog.Leveler that follows a cakelog.Leveler.
+type slogLeveler struct {
+	// The followed leveler.
+	leveler cakelog.Leveler
+}
+
+// Implements slog.Leveler.
+func (sl slogLeveler) Level() slog.Level {
+	return slog.Level(sl.leveler.Level())
+}
+
+// Helper function to convert fields to slog attributes, with nested fields and maps becoming slog groups.
+// Values implementing slog.LogValuer, such as lazy values, are kept as they are,
+// since slog handlers resolve them only when they write the entry.
+func slogAttrs(fields []cakelog.Field) []slog.Attr {
+	attrs := make([]slog.Attr, 0, len(fields))
+
+	for _, field := range fields {
+		attrs = append(attrs, slogAttr(field.Key, field.Value))
+	}
+
+	return attrs
+}
+
+// Helper function to convert a single value to a slog attribute, converting groups and maps to slog groups.
+func slogAttr(key string, value any) slog.Attr {
+	switch value := value.(type) {
+	case []cakelog.Field:
+		return slog.Attr{Key: key, Value: slog.GroupValue(slogAttrs(value)...)}
+	case slog.LogValuer, slog.Value, nil:
+		return slog.Any(key, value)
+	default:
+		if reflect.TypeOf(value).Kind() == reflect.Map {
+			return slog.Attr{Key: key, Value: slog.GroupValue(slogAttrs(cakelog.Normalize(value))...)}
+		}
+
+		return slog.Any(key, value)
+	}
+}
+
+var (
+	// Ensures that slogLeveler implements the slog.Leveler interface.
+	_ slog.Leveler = slogLeveler{}
+
+	// Ensures that SlogLogger implements the cakelog.Logger interface.
+	_ cakelog.Logger = (*SlogLogger)(nil)
+
+	// Ensures that SlogLogger implements the cakelog.LevelLogger interface.
+	_ cakelog.LevelLogger = (*SlogLogger)(nil)
+
+	// Ensures that SlogLogger implements the cakelog.Handler interface.
+	_ cakelog.Handler = (*SlogLogger)(nil)
+
+	// Ensures that SlogLogger implements the cakelog.WithLogger interface.
+	_ cakelog.WithLogger = (*SlogLogger)(nil)
+
+	// Ensures that SlogLogger implements the cakelog.Enabler interface.
+	_ cakelog.Enabler = (*SlogLogger)(nil)
+
+	// Ensures that SlogLogger implements the cakelog.GroupLogger interface.
+	_ cakelog.GroupLogger = (*SlogLogger)(nil)
+)
@@ -1,11 +1,17 @@
 package adapter_test
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"fmt"
+	"io"
 	"log/slog"
+	"runtime"
+	"strings"
 	"testing"
 
+	"github.com/yuppyweb/cakelog"
 	"github.com/yuppyweb/cakelog/adapter"
 )
 
@@ -35,11 +41,22 @@ func (h *mockSlogHandler) Enabled(context.Context, slog.Level) bool {
 
 var _ slog.Handler = (*mockSlogHandler)(nil)
 
+func slogGroupValue(values []any) slog.Value {
+	attrs := make([]slog.Attr, 0, len(values)/2)
+
+	for idx := 0; idx+1 < len(values); idx += 2 {
+		attrs = append(attrs, slog.Any(fmt.Sprint(values[idx]), values[idx+1]))
+	}
+
+	return slog.GroupValue(attrs...)
+}
+
 func TestSlogLogger_DebugWithDefaultArgsKey(t *testing.T) {
 	t.Parallel()
 
 	handler := new(mockSlogHandler)
 	log := adapter.NewSlogLogger(slog.New(handler))
+	log.ArgsKey = adapter.LegacyArgsKey
 
 	ctx := context.Background()
 	ctx = context.WithValue(ctx, "debugTestKey", "debug test value")
@@ -68,14 +85,14 @@ func TestSlogLogger_DebugWithDefaultArgsKey(t *testing.T) {
 	checkedAttr := false
 
 	record.Attrs(func(attr slog.Attr) bool {
-		if attr.Key != adapter.DefaultSlogArgsKey {
-			t.Errorf("expected attribute key '%s', got '%s'", adapter.DefaultSlogArgsKey, attr.Key)
+		if attr.Key != adapter.LegacyArgsKey {
+			t.Errorf("expected attribute key '%s', got '%s'", adapter.LegacyArgsKey, attr.Key)
 		}
 
-		if attr.Value.String() != slog.AnyValue(values).String() {
+		if attr.Value.String() != slogGroupValue(values).String() {
 			t.Errorf(
 				"expected attribute value '%s', got '%s'",
-				slog.AnyValue(values).String(),
+				slogGroupValue(values).String(),
 				attr.Value.String(),
 			)
 		}
@@ -103,6 +120,7 @@ func TestSlogLogger_InfoWithDefaultArgsKey(t *testing.T) {
 
 	handler := new(mockSlogHandler)
 	log := adapter.NewSlogLogger(slog.New(handler))
+	log.ArgsKey = adapter.LegacyArgsKey
 
 	ctx := context.Background()
 	ctx = context.WithValue(ctx, "infoTestKey", "info test value")
@@ -131,14 +149,14 @@ func TestSlogLogger_InfoWithDefaultArgsKey(t *testing.T) {
 	checkedAttr := false
 
 	record.Attrs(func(attr slog.Attr) bool {
-		if attr.Key != adapter.DefaultSlogArgsKey {
-			t.Errorf("expected attribute key '%s', got '%s'", adapter.DefaultSlogArgsKey, attr.Key)
+		if attr.Key != adapter.LegacyArgsKey {
+			t.Errorf("expected attribute key '%s', got '%s'", adapter.LegacyArgsKey, attr.Key)
 		}
 
-		if attr.Value.String() != slog.AnyValue(values).String() {
+		if attr.Value.String() != slogGroupValue(values).String() {
 			t.Errorf(
 				"expected attribute value '%s', got '%s'",
-				slog.AnyValue(values).String(),
+				slogGroupValue(values).String(),
 				attr.Value.String(),
 			)
 		}
@@ -166,6 +184,7 @@ func TestSlogLogger_WarnWithDefaultArgsKey(t *testing.T) {
 
 	handler := new(mockSlogHandler)
 	log := adapter.NewSlogLogger(slog.New(handler))
+	log.ArgsKey = adapter.LegacyArgsKey
 
 	ctx := context.Background()
 	ctx = context.WithValue(ctx, "warnTestKey", "warn test value")
@@ -194,14 +213,14 @@ func TestSlogLogger_WarnWithDefaultArgsKey(t *testing.T) {
 	checkedAttr := false
 
 	record.Attrs(func(attr slog.Attr) bool {
-		if attr.Key != adapter.DefaultSlogArgsKey {
-			t.Errorf("expected attribute key '%s', got '%s'", adapter.DefaultSlogArgsKey, attr.Key)
+		if attr.Key != adapter.LegacyArgsKey {
+			t.Errorf("expected attribute key '%s', got '%s'", adapter.LegacyArgsKey, attr.Key)
 		}
 
-		if attr.Value.String() != slog.AnyValue(values).String() {
+		if attr.Value.String() != slogGroupValue(values).String() {
 			t.Errorf(
 				"expected attribute value '%s', got '%s'",
-				slog.AnyValue(values).String(),
+				slogGroupValue(values).String(),
 				attr.Value.String(),
 			)
 		}
@@ -229,6 +248,7 @@ func TestSlogLogger_ErrorWithDefaultArgsKey(t *testing.T) {
 
 	handler := new(mockSlogHandler)
 	log := adapter.NewSlogLogger(slog.New(handler))
+	log.ArgsKey = adapter.LegacyArgsKey
 
 	ctx := context.Background()
 	ctx = context.WithValue(ctx, "errorTestKey", "error test value")
@@ -250,21 +270,29 @@ func TestSlogLogger_ErrorWithDefaultArgsKey(t *testing.T) {
 		t.Errorf("expected level Error, got %s", record.Level)
 	}
 
-	if record.NumAttrs() != 1 {
-		t.Fatalf("expected 1 attribute, got %d", record.NumAttrs())
+	if record.NumAttrs() != 2 {
+		t.Fatalf("expected 2 attributes, got %d", record.NumAttrs())
 	}
 
 	checkedAttr := false
 
 	record.Attrs(func(attr slog.Attr) bool {
-		if attr.Key != adapter.DefaultSlogArgsKey {
-			t.Errorf("expected attribute key '%s', got '%s'", adapter.DefaultSlogArgsKey, attr.Key)
+		if attr.Key == cakelog.ErrorKey {
+			if attr.Value.String() != "error message" {
+				t.Errorf("expected the error attribute to be 'error message', got '%s'", attr.Value.String())
+			}
+
+			return true
 		}
 
-		if attr.Value.String() != slog.AnyValue(values).String() {
+		if attr.Key != adapter.LegacyArgsKey {
+			t.Errorf("expected attribute key '%s', got '%s'", adapter.LegacyArgsKey, attr.Key)
+		}
+
+		if attr.Value.String() != slogGroupValue(values).String() {
 			t.Errorf(
 				"expected attribute value '%s', got '%s'",
-				slog.AnyValue(values).String(),
+				slogGroupValue(values).String(),
 				attr.Value.String(),
 			)
 		}
@@ -325,10 +353,10 @@ func TestSlogLogger_DebugWithCustomArgsKey(t *testing.T) {
 			t.Errorf("expected attribute key 'debugArgs', got '%s'", attr.Key)
 		}
 
-		if attr.Value.String() != slog.AnyValue(values).String() {
+		if attr.Value.String() != slogGroupValue(values).String() {
 			t.Errorf(
 				"expected attribute value '%s', got '%s'",
-				slog.AnyValue(values).String(),
+				slogGroupValue(values).String(),
 				attr.Value.String(),
 			)
 		}
@@ -389,10 +417,10 @@ func TestSlogLogger_InfoWithCustomArgsKey(t *testing.T) {
 			t.Errorf("expected attribute key 'infoArgs', got '%s'", attr.Key)
 		}
 
-		if attr.Value.String() != slog.AnyValue(values).String() {
+		if attr.Value.String() != slogGroupValue(values).String() {
 			t.Errorf(
 				"expected attribute value '%s', got '%s'",
-				slog.AnyValue(values).String(),
+				slogGroupValue(values).String(),
 				attr.Value.String(),
 			)
 		}
@@ -453,10 +481,10 @@ func TestSlogLogger_WarnWithCustomArgsKey(t *testing.T) {
 			t.Errorf("expected attribute key 'warnArgs', got '%s'", attr.Key)
 		}
 
-		if attr.Value.String() != slog.AnyValue(values).String() {
+		if attr.Value.String() != slogGroupValue(values).String() {
 			t.Errorf(
 				"expected attribute value '%s', got '%s'",
-				slog.AnyValue(values).String(),
+				slogGroupValue(values).String(),
 				attr.Value.String(),
 			)
 		}
@@ -506,21 +534,29 @@ func TestSlogLogger_ErrorWithCustomArgsKey(t *testing.T) {
 		t.Errorf("expected level Error, got %s", record.Level)
 	}
 
-	if record.NumAttrs() != 1 {
-		t.Fatalf("expected 1 attribute, got %d", record.NumAttrs())
+	if record.NumAttrs() != 2 {
+		t.Fatalf("expected 2 attributes, got %d", record.NumAttrs())
 	}
 
 	checkedAttr := false
 
 	record.Attrs(func(attr slog.Attr) bool {
+		if attr.Key == cakelog.ErrorKey {
+			if attr.Value.String() != "error message" {
+				t.Errorf("expected the error attribute to be 'error message', got '%s'", attr.Value.String())
+			}
+
+			return true
+		}
+
 		if attr.Key != "errorArgs" {
 			t.Errorf("expected attribute key 'errorArgs', got '%s'", attr.Key)
 		}
 
-		if attr.Value.String() != slog.AnyValue(values).String() {
+		if attr.Value.String() != slogGroupValue(values).String() {
 			t.Errorf(
 				"expected attribute value '%s', got '%s'",
-				slog.AnyValue(values).String(),
+				slogGroupValue(values).String(),
 				attr.Value.String(),
 			)
 		}
@@ -542,3 +578,335 @@ func TestSlogLogger_ErrorWithCustomArgsKey(t *testing.T) {
 		t.Errorf("expected context %v, got %v", ctx, handler.contexts[0])
 	}
 }
+
+func TestSlogLogger_LogWithCustomLevel(t *testing.T) {
+	t.Parallel()
+
+	handler := new(mockSlogHandler)
+	log := adapter.NewSlogLogger(slog.New(handler))
+
+	log.Log(context.Background(), cakelog.LevelWarn+2, "", errors.New("log error"), "log", 1)
+
+	if len(handler.records) != 1 {
+		t.Fatalf("expected 1 log record, got %d", len(handler.records))
+	}
+
+	record := handler.records[0]
+
+	if record.Level != slog.LevelWarn+2 {
+		t.Errorf("expected level %s, got %s", slog.LevelWarn+2, record.Level)
+	}
+
+	if record.Message != "log error" {
+		t.Errorf("expected message 'log error', got '%s'", record.Message)
+	}
+}
+
+func TestSlogLogger_NormalizedArgs(t *testing.T) {
+	t.Parallel()
+
+	buf := &bytes.Buffer{}
+	log := adapter.NewSlogLogger(slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{
+		ReplaceAttr: func(_ []string, attr slog.Attr) slog.Attr {
+			if attr.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+
+			return attr
+		},
+	})))
+
+	log.Info(
+		context.Background(),
+		"info message",
+		"user", 42,
+		slog.Group("req", slog.String("method", "GET")),
+		"dangling",
+	)
+
+	expected := `{"level":"INFO","msg":"info message",` +
+		`"user":42,"req":{"method":"GET"},"!BADKEY":"dangling"}` + "\n"
+
+	if buf.String() != expected {
+		t.Errorf("unexpected log output:\nGot:  %s\nWant: %s", buf.String(), expected)
+	}
+}
+
+func TestSlogLogger_WithAndWithGroup(t *testing.T) {
+	t.Parallel()
+
+	buf := &bytes.Buffer{}
+	log := adapter.NewSlogLogger(slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{
+		ReplaceAttr: func(_ []string, attr slog.Attr) slog.Attr {
+			if attr.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+
+			return attr
+		},
+	})))
+
+	derived := cakelog.With(cakelog.WithGroup(cakelog.With(log, "service", "api"), "http"), "method", "GET")
+
+	if _, ok := derived.(*adapter.SlogLogger); !ok {
+		t.Fatalf("expected native *adapter.SlogLogger, got %T", derived)
+	}
+
+	derived.Info(context.Background(), "info message", "status", 200)
+
+	expected := `{"level":"INFO","msg":"info message","service":"api",` +
+		`"http":{"method":"GET","status":200}}` + "\n"
+
+	if buf.String() != expected {
+		t.Errorf("unexpected log output:\nGot:  %s\nWant: %s", buf.String(), expected)
+	}
+}
+
+func TestSlogLogger_Enabled(t *testing.T) {
+	t.Parallel()
+
+	logger := adapter.NewSlogLogger(slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{
+		Level: slog.LevelWarn,
+	})))
+
+	if logger.Enabled(context.Background(), cakelog.LevelInfo) {
+		t.Error("expected info to be disabled")
+	}
+
+	if !logger.Enabled(context.Background(), cakelog.LevelWarn) {
+		t.Error("expected warn to be enabled")
+	}
+}
+
+func TestSlogLogger_LogTraceFatalAndPanic(t *testing.T) {
+	t.Parallel()
+
+	buf := &bytes.Buffer{}
+	log := adapter.NewSlogLogger(slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{
+		Level: adapter.SlogLevelTrace,
+		ReplaceAttr: func(groups []string, attr slog.Attr) slog.Attr {
+			if attr.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+
+			return adapter.SlogReplaceLevel(groups, attr)
+		},
+	})))
+
+	log.Log(context.Background(), cakelog.LevelTrace, "trace message", nil)
+	log.Log(context.Background(), cakelog.LevelFatal, "fatal message", nil)
+	log.Log(context.Background(), cakelog.LevelPanic, "panic message", nil)
+	log.Log(context.Background(), cakelog.LevelError, "error message", nil)
+
+	expected := "level=TRACE msg=\"trace message\"\n" +
+		"level=FATAL msg=\"fatal message\"\n" +
+		"level=PANIC msg=\"panic message\"\n" +
+		"level=ERROR msg=\"error message\"\n"
+
+	if buf.String() != expected {
+		t.Errorf("unexpected log output:\nGot:  %s\nWant: %s", buf.String(), expected)
+	}
+}
+
+func TestSlogLogger_Handle(t *testing.T) {
+	t.Parallel()
+
+	handler := &mockSlogHandler{}
+	logger := adapter.NewSlogLogger(slog.New(handler))
+	expectedErr := errors.New("connection refused")
+
+	cakelog.NewSugar(logger).Infof(context.Background(), "took %dms", 15)
+	logger.Handle(cakelog.NewRecord(context.Background(), cakelog.LevelWarn, "retrying", expectedErr))
+
+	if len(handler.records) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(handler.records))
+	}
+
+	record := handler.records[0]
+
+	if record.Message != "took 15ms" || record.Level != slog.LevelInfo {
+		t.Errorf("unexpected record: %v %q", record.Level, record.Message)
+	}
+
+	frame, _ := runtime.CallersFrames([]uintptr{record.PC}).Next()
+
+	if !strings.HasSuffix(frame.Function, "TestSlogLogger_Handle") {
+		t.Errorf("expected the source to be TestSlogLogger_Handle, got %q", frame.Function)
+	}
+
+	expected := slog.String(cakelog.DefaultFormatKey, "took %dms")
+
+	record.Attrs(func(attr slog.Attr) bool {
+		if !attr.Equal(expected) {
+			t.Errorf("unexpected attribute:\nGot:  %v\nWant: %v", attr, expected)
+		}
+
+		return true
+	})
+
+	var attrs []slog.Attr
+
+	handler.records[1].Attrs(func(attr slog.Attr) bool {
+		attrs = append(attrs, attr)
+
+		return true
+	})
+
+	if handler.records[1].Message != "retrying" {
+		t.Errorf("expected the message to be kept next to the error, got %q", handler.records[1].Message)
+	}
+
+	expected = slog.Any(cakelog.ErrorKey, expectedErr)
+
+	if len(attrs) != 1 || !attrs[0].Equal(expected) {
+		t.Errorf("unexpected attributes:\nGot:  %v\nWant: %v", attrs, expected)
+	}
+}
+
+func TestSlogLogger_LazyArgs(t *testing.T) {
+	t.Parallel()
+
+	buf := &bytes.Buffer{}
+	logger := adapter.NewSlogLogger(slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{
+		Level: slog.LevelInfo,
+		ReplaceAttr: func(_ []string, attr slog.Attr) slog.Attr {
+			if attr.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+
+			return attr
+		},
+	})))
+	calls := 0
+	body := cakelog.LazyField("body", func() any {
+		calls++
+
+		return "payload"
+	})
+
+	logger.Debug(context.Background(), "debug message", body)
+
+	if calls != 0 {
+		t.Fatalf("expected the lazy value not to be resolved for a disabled level, got %d calls", calls)
+	}
+
+	logger.Info(context.Background(), "info message", body)
+
+	expected := `{"level":"INFO","msg":"info message","body":"payload"}` + "\n"
+
+	if buf.String() != expected {
+		t.Errorf("unexpected log output:\nGot:  %s\nWant: %s", buf.String(), expected)
+	}
+
+	if calls != 1 {
+		t.Errorf("expected the lazy value to be resolved once, got %d calls", calls)
+	}
+}
+
+func TestSlogLeveler(t *testing.T) {
+	t.Parallel()
+
+	levelVar := cakelog.NewLevelVar(cakelog.LevelInfo)
+	logger := adapter.NewSlogLogger(slog.New(slog.NewJSONHandler(io.Discard, &slog.HandlerOptions{
+		Level: adapter.SlogLeveler(levelVar),
+	})))
+
+	if logger.Enabled(context.Background(), cakelog.LevelDebug) {
+		t.Error("expected debug to be disabled")
+	}
+
+	levelVar.Set(cakelog.LevelTrace)
+
+	if !logger.Enabled(context.Background(), cakelog.LevelTrace) {
+		t.Error("expected trace to be enabled after changing the level")
+	}
+}
+
+type slogUser struct {
+	id   int
+	name string
+}
+
+func (u slogUser) LogValue() slog.Value {
+	return slog.GroupValue(slog.Int("id", u.id), slog.String("name", u.name))
+}
+
+func TestSlogLogger_NativeAttrs(t *testing.T) {
+	t.Parallel()
+
+	buf := &bytes.Buffer{}
+	logger := adapter.NewSlogLogger(slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{
+		ReplaceAttr: func(_ []string, attr slog.Attr) slog.Attr {
+			if attr.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+
+			return attr
+		},
+	})))
+
+	logger.Info(context.Background(), "info message",
+		"user", slogUser{id: 42, name: "alice"},
+		"labels", map[string]any{"zone": "eu", "limits": map[string]int{"cpu": 2}},
+		"ids", []int{1, 2},
+	)
+
+	expected := `{"level":"INFO","msg":"info message","user":{"id":42,"name":"alice"},` +
+		`"labels":{"limits":{"cpu":2},"zone":"eu"},"ids":[1,2]}` + "\n"
+
+	if buf.String() != expected {
+		t.Errorf("unexpected log output:\nGot:  %s\nWant: %s", buf.String(), expected)
+	}
+}
+
+func TestSlogLogger_HandleWithArgsKey(t *testing.T) {
+	t.Parallel()
+
+	handler := new(mockSlogHandler)
+	logger := adapter.NewSlogLogger(slog.New(handler))
+	logger.ArgsKey = adapter.LegacyArgsKey
+
+	logger.Handle(cakelog.NewRecord(context.Background(), cakelog.LevelInfo, "info message", nil, "user", 42))
+
+	if len(handler.records) != 1 {
+		t.Fatalf("expected 1 record, got %d", len(handler.records))
+	}
+
+	expected := slog.Group(adapter.LegacyArgsKey, slog.Int("user", 42))
+
+	handler.records[0].Attrs(func(attr slog.Attr) bool {
+		if !attr.Equal(expected) {
+			t.Errorf("unexpected attribute:\nGot:  %v\nWant: %v", attr, expected)
+		}
+
+		return true
+	})
+}
+
+func TestSlogLogger_Source(t *testing.T) {
+	t.Parallel()
+
+	handler := &mockSlogHandler{}
+	logger := adapter.NewSlogLogger(slog.New(handler))
+	ctx := context.Background()
+
+	_, file, line, _ := runtime.Caller(0)
+
+	logger.Debug(ctx, "debug message")
+	logger.Info(ctx, "info message")
+	logger.Warn(ctx, "warn message")
+	logger.Error(ctx, errors.New("error message"))
+	logger.Log(ctx, cakelog.LevelError, "log message", nil)
+
+	if len(handler.records) != 5 {
+		t.Fatalf("expected 5 records, got %d", len(handler.records))
+	}
+
+	for idx, record := range handler.records {
+		source := record.Source()
+
+		if source == nil || source.File != file || source.Line != line+2+idx {
+			t.Errorf("expected the source of %q to be %s:%d, got %+v", record.Message, file, line+2+idx, source)
+		}
+	}
+}
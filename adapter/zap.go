@@ -2,50 +2,506 @@ package adapter
 
 import (
 	"context"
+	"fmt"
+	"runtime"
+	"sync"
+	"time"
 
 	"github.com/yuppyweb/cakelog"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 )
 
-// Is the default key under which context arguments will be stored in zap entries.
-const DefaultZapArgsKey = "context"
+// Is the key under which ZapLogger nested the arguments before they became top-level fields.
+//
+// Deprecated: Use LegacyArgsKey.
+const DefaultZapArgsKey = LegacyArgsKey
+
+// Is the largest capacity of a field buffer returned to the pool, so a single entry with many arguments
+// does not keep a large buffer alive.
+const zapFieldBufferMaxCap = 64
+
+// Is the pool of the buffers in which the zap fields of an entry are built if ZapLogger.PoolFields is set.
+//
+//nolint:gochecknoglobals // A pool is shared by all loggers.
+var zapFieldPool = sync.Pool{
+	New: func() any {
+		fields := make([]zap.Field, 0, zapFieldBufferMaxCap/4)
+
+		return &fields
+	},
+}
+
+// Is a function that appends zap fields taken from the context of an entry to fields and returns the result,
+// such as the trace and span IDs of the current request.
+// It is called for every entry that is written, so it should be cheap and append nothing if the context lacks
+// its values. The fields are written next to the error, before the arguments.
+type ZapContextExtractor func(ctx context.Context, fields []zap.Field) []zap.Field
+
+// Returns a ZapContextExtractor that adds the value stored in the context under key as a typed field named name,
+// the way the arguments are converted. Nothing is added if the context has no value for the key.
+func ZapContextValue(name string, key any) ZapContextExtractor {
+	return func(ctx context.Context, fields []zap.Field) []zap.Field {
+		value := ctx.Value(key)
+		if value == nil {
+			return fields
+		}
+
+		return append(fields, zapField(name, value))
+	}
+}
 
 // Is an adapter that allows using a zap.Logger as a cakelog.Logger.
 type ZapLogger struct {
 	// The underlying zap.Logger to which log messages will be forwarded.
 	logger *zap.Logger
 
-	// The key under which the context arguments will be stored in zap entries.
+	// The key of an object under which the arguments are nested, as a compatibility mode.
+	// If empty, which is the default, the arguments are written as top-level zap fields, which is the fastest mode.
 	ArgsKey string
+
+	// The extractors called in order with the context of every entry that is written, to add fields from it.
+	// Loggers derived with With and WithGroup share them.
+	ContextExtractors []ZapContextExtractor
+
+	// Whether the zap fields of an entry are built in a pooled buffer, which is cleared and reused as soon as
+	// zapcore.Core.Write returns, saving the allocation of the fields. By default, every entry hands the cores
+	// its own fields, which they may keep. It may only be set if no core keeps the fields after Write returns:
+	// the cores of zapcore.NewCore encode them right away, but buffering or asynchronous cores and zaptest/observer
+	// keep them. Loggers derived with With and WithGroup share it.
+	PoolFields bool
+
+	// The fields and groups bound from the first lazy value on, which are added to every entry that is written,
+	// so the lazy values are computed for it.
+	groups []boundGroup
 }
 
 // Creates a new ZapLogger that wraps the provided zap.Logger.
 func NewZapLogger(logger *zap.Logger) *ZapLogger {
-	return &ZapLogger{
-		logger:  logger,
-		ArgsKey: DefaultZapArgsKey,
-	}
+	return &ZapLogger{logger: logger}
 }
 
 // Sends a debug message to the underlying zap.Logger with the provided context and arguments.
-func (zl *ZapLogger) Debug(_ context.Context, msg string, args ...any) {
-	zl.logger.Debug(msg, zap.Any(zl.ArgsKey, args))
+func (zl *ZapLogger) Debug(ctx context.Context, msg string, args ...any) {
+	zl.log(ctx, cakelog.LevelDebug, msg, nil, args)
 }
 
 // Sends an info message to the underlying zap.Logger with the provided context and arguments.
-func (zl *ZapLogger) Info(_ context.Context, msg string, args ...any) {
-	zl.logger.Info(msg, zap.Any(zl.ArgsKey, args))
+func (zl *ZapLogger) Info(ctx context.Context, msg string, args ...any) {
+	zl.log(ctx, cakelog.LevelInfo, msg, nil, args)
 }
 
 // Sends a warning message to the underlying zap.Logger with the provided context and arguments.
-func (zl *ZapLogger) Warn(_ context.Context, msg string, args ...any) {
-	zl.logger.Warn(msg, zap.Any(zl.ArgsKey, args))
+func (zl *ZapLogger) Warn(ctx context.Context, msg string, args ...any) {
+	zl.log(ctx, cakelog.LevelWarn, msg, nil, args)
 }
 
 // Sends an error message to the underlying zap.Logger with the provided context, error, and arguments.
-func (zl *ZapLogger) Error(_ context.Context, err error, args ...any) {
-	zl.logger.Error(err.Error(), zap.Any(zl.ArgsKey, args))
+func (zl *ZapLogger) Error(ctx context.Context, err error, args ...any) {
+	zl.log(ctx, cakelog.LevelError, "", err, args)
+}
+
+// Sends a message at the given level to the underlying zap.Logger with the provided context, error, and arguments.
+// The error is written with zap.Error, and its text is used if the message is empty.
+func (zl *ZapLogger) Log(ctx context.Context, level cakelog.Level, msg string, err error, args ...any) {
+	zl.log(ctx, level, msg, err, args)
+}
+
+// Sends an existing record to the underlying zap.Logger, keeping its time,
+// with the fields the ContextExtractors take from the context of the record.
+// If the zap.Logger adds callers, the caller of the entry is taken from the program counter of the record,
+// so it is the original call site instead of this adapter.
+func (zl *ZapLogger) Handle(record cakelog.Record) {
+	err := entryError(record.Err)
+
+	ce := zl.logger.Check(ZapLevel(record.Level), entryMessage(record.Message, err))
+	if ce == nil {
+		return
+	}
+
+	if !record.Time.IsZero() {
+		ce.Time = record.Time
+	}
+
+	setZapCaller(ce, record.PC)
+
+	zl.write(record.Context, ce.After(ce.Entry, zapcore.WriteThenNoop), err, record.Attrs, nil)
+}
+
+// Reports whether the underlying zap core would write a message at the given level.
+func (zl *ZapLogger) Enabled(_ context.Context, level cakelog.Level) bool {
+	return zl.logger.Core().Enabled(ZapLevel(level))
+}
+
+// Flushes the entries buffered by the underlying zap core with zap.Logger.Sync.
+func (zl *ZapLogger) Sync(context.Context) error {
+	return zl.logger.Sync()
+}
+
+// Returns a ZapLogger whose underlying zap.Logger has the given arguments bound with zap.Logger.With.
+// The bound arguments are encoded once and added at the top level of every entry, next to the ArgsKey object.
+// Zap encodes bound fields right away, so from the first argument holding a lazy value on,
+// the arguments and groups are kept apart instead and added to every entry that is written, in the same place.
+func (zl *ZapLogger) With(args ...any) cakelog.Logger {
+	fields, groups := bindGroupFields(zl.groups, cakelog.Normalize(args...))
+
+	logger := zl.logger
+	if len(fields) > 0 {
+		zapList := make([]zap.Field, 0, len(fields))
+
+		for _, field := range fields {
+			zapList = append(zapList, zapField(field.Key, field.Value))
+		}
+
+		logger = logger.With(zapList...)
+	}
+
+	return &ZapLogger{
+		logger:            logger,
+		ArgsKey:           zl.ArgsKey,
+		ContextExtractors: zl.ContextExtractors,
+		PoolFields:        zl.PoolFields,
+		groups:            groups,
+	}
+}
+
+// Returns a ZapLogger whose underlying zap.Logger nests all further fields in a zap.Namespace,
+// or that opens the namespace itself once lazy values are bound.
+func (zl *ZapLogger) WithGroup(name string) cakelog.Logger {
+	groups := bindGroup(zl.groups, name)
+
+	logger := zl.logger
+	if groups == nil {
+		logger = logger.With(zap.Namespace(name))
+	}
+
+	return &ZapLogger{
+		logger:            logger,
+		ArgsKey:           zl.ArgsKey,
+		ContextExtractors: zl.ContextExtractors,
+		PoolFields:        zl.PoolFields,
+		groups:            groups,
+	}
+}
+
+// Helper method to write an entry with the program counter of the caller of the logging method,
+// so zap reports the call site as the caller whichever method was called.
+func (zl *ZapLogger) log(ctx context.Context, level cakelog.Level, msg string, err error, args []any) {
+	err = entryError(err)
+
+	ce := zl.logger.Check(ZapLevel(level), entryMessage(msg, err))
+	if ce == nil {
+		return
+	}
+
+	if ce.Caller.Defined {
+		var pcs [1]uintptr
+
+		// Skips runtime.Callers, this method and the logging method.
+		runtime.Callers(3, pcs[:])
+		setZapCaller(ce, pcs[0])
+	}
+
+	if zl.ArgsKey != "" {
+		zl.write(ctx, ce.After(ce.Entry, zapcore.WriteThenNoop), err, cakelog.Normalize(args...), nil)
+
+		return
+	}
+
+	zl.write(ctx, ce.After(ce.Entry, zapcore.WriteThenNoop), err, nil, args)
+}
+
+// Helper function to replace the caller of an entry with the frame of the program counter,
+// if the zap.Logger adds callers and the program counter is known.
+func setZapCaller(ce *zapcore.CheckedEntry, pc uintptr) {
+	if !ce.Caller.Defined || pc == 0 {
+		return
+	}
+
+	frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+	ce.Caller = zapcore.NewEntryCaller(frame.PC, frame.File, frame.Line, true)
+	ce.Caller.Function = frame.Function
+}
+
+// Helper method to write an entry with the zap fields of the bound groups, then of the error and its chain, if any,
+// and of the context extractors, followed by the fields and the arguments, either at the top level
+// or, for the fields, in the ArgsKey object. The arguments are converted without normalizing them first.
+// The zap fields are built in a new slice, or in a pooled buffer returned to the pool after the write if PoolFields
+// is set.
+func (zl *ZapLogger) write(
+	ctx context.Context,
+	ce *zapcore.CheckedEntry,
+	err error,
+	fields []cakelog.Field,
+	args []any,
+) {
+	var (
+		buf     *[]zap.Field
+		zapList []zap.Field
+	)
+
+	if zl.PoolFields {
+		buf, _ = zapFieldPool.Get().(*[]zap.Field)
+	}
+
+	if buf != nil {
+		zapList = *buf
+	} else {
+		zapList = make([]zap.Field, 0, zl.fieldsLen(err, fields, args))
+	}
+
+	for idx, group := range zl.groups {
+		if idx > 0 {
+			zapList = append(zapList, zap.Namespace(group.name))
+		}
+
+		for _, field := range cakelog.RenewLazy(group.fields) {
+			zapList = append(zapList, zapField(field.Key, field.Value))
+		}
+	}
+
+	if err != nil {
+		zapList = append(zapList, zap.Error(err))
+
+		if cakelog.HasErrorChain(err) {
+			zapList = append(zapList, zap.Array(cakelog.ErrorChainKey, zapErrorChain(cakelog.ErrorChain(err))))
+		}
+	}
+
+	if ctx != nil {
+		for _, extract := range zl.ContextExtractors {
+			zapList = extract(ctx, zapList)
+		}
+	}
+
+	if zl.ArgsKey == "" {
+		for _, field := range fields {
+			zapList = append(zapList, zapField(field.Key, field.Value))
+		}
+
+		zapList = appendZapArgs(zapList, args)
+	} else {
+		zapList = append(zapList, zap.Object(zl.ArgsKey, zapFields(fields)))
+	}
+
+	ce.Write(zapList...)
+
+	if buf != nil && cap(zapList) <= zapFieldBufferMaxCap {
+		clear(zapList)
+		*buf = zapList[:0]
+		zapFieldPool.Put(buf)
+	}
+}
+
+// Helper method to estimate the number of zap fields of an entry, so they are usually allocated at once.
+func (zl *ZapLogger) fieldsLen(err error, fields []cakelog.Field, args []any) int {
+	size := len(zl.ContextExtractors) + len(fields)
+
+	if err != nil {
+		size += 2
+	}
+
+	for _, group := range zl.groups {
+		size += len(group.fields) + 1
+	}
+
+	for idx := 0; idx < len(args); idx++ {
+		if _, ok := args[idx].(string); ok {
+			idx++
+		}
+
+		size++
+	}
+
+	return size
+}
+
+// Helper function to append the arguments of a log call to zap fields as typed fields, following the rules
+// of cakelog.Normalize. Key-value pairs and fields are converted directly, which saves allocating a list of fields,
+// and the other arguments, such as slog attributes and maps, are normalized one by one.
+func appendZapArgs(zapList []zap.Field, args []any) []zap.Field {
+	for idx := 0; idx < len(args); idx++ {
+		switch arg := args[idx].(type) {
+		case string:
+			if idx+1 < len(args) {
+				idx++
+				zapList = append(zapList, zapField(arg, args[idx]))
+
+				continue
+			}
+		case cakelog.Field:
+			zapList = append(zapList, zapField(arg.Key, arg.Value))
+
+			continue
+		}
+
+		for _, field := range cakelog.Normalize(args[idx]) {
+			zapList = append(zapList, zapField(field.Key, field.Value))
+		}
+	}
+
+	return zapList
+}
+
+// Helper function to convert a value to a typed zap field without reflection for the common types:
+// strings, integers, floats, booleans, durations, times, errors, byte slices, fmt.Stringer values,
+// and zapcore.ObjectMarshaler and zapcore.ArrayMarshaler implementations. Lazy values are resolved,
+// groups become nested objects, and other values fall back to zap.Any.
+//
+//nolint:cyclop,gocyclo // A flat type switch is the clearest way to map the types.
+func zapField(key string, value any) zap.Field {
+	switch value := cakelog.Resolve(value).(type) {
+	case string:
+		return zap.String(key, value)
+	case int:
+		return zap.Int(key, value)
+	case int64:
+		return zap.Int64(key, value)
+	case int32:
+		return zap.Int32(key, value)
+	case uint:
+		return zap.Uint(key, value)
+	case uint64:
+		return zap.Uint64(key, value)
+	case float64:
+		return zap.Float64(key, value)
+	case bool:
+		return zap.Bool(key, value)
+	case time.Duration:
+		return zap.Duration(key, value)
+	case time.Time:
+		return zap.Time(key, value)
+	case error:
+		return zap.NamedError(key, value)
+	case []byte:
+		return zap.ByteString(key, value)
+	case []cakelog.Field:
+		return zap.Object(key, zapFields(value))
+	case zapcore.ObjectMarshaler:
+		return zap.Object(key, value)
+	case zapcore.ArrayMarshaler:
+		return zap.Array(key, value)
+	case fmt.Stringer:
+		return zap.Stringer(key, value)
+	default:
+		return zap.Any(key, value)
+	}
+}
+
+// Is an error chain encoded by zap as an array of objects.
+type zapErrorChain []cakelog.ErrorDetail
+
+// Implements zapcore.ArrayMarshaler.
+func (zc zapErrorChain) MarshalLogArray(enc zapcore.ArrayEncoder) error {
+	for _, detail := range zc {
+		if err := enc.AppendObject(zapErrorDetail(detail)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Is a single error of a chain encoded by zap as an object with its message, type and fields.
+type zapErrorDetail cakelog.ErrorDetail
+
+// Implements zapcore.ObjectMarshaler.
+func (zd zapErrorDetail) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("message", zd.Message)
+	enc.AddString("type", zd.Type)
+
+	if len(zd.Fields) == 0 {
+		return nil
+	}
+
+	return enc.AddObject("fields", zapFields(zd.Fields))
+}
+
+// Is a list of fields encoded by zap as an object, with nested fields becoming nested objects.
+type zapFields []cakelog.Field
+
+// Implements zapcore.ObjectMarshaler with the typed fields of zapField.
+// Lazy values are resolved here, when the entry is encoded.
+func (zf zapFields) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	for _, field := range zf {
+		zapField(field.Key, field.Value).AddTo(enc)
+	}
+
+	return nil
+}
+
+// Is a zapcore.LevelEnabler that follows a cakelog.Leveler, such as a cakelog.LevelVar,
+// to be passed to zapcore.NewCore, so the level of the core can be changed at runtime.
+type ZapLevelEnabler struct {
+	// The followed leveler.
+	leveler cakelog.Leveler
+}
+
+// Creates a new ZapLevelEnabler that follows the provided cakelog.Leveler.
+func NewZapLevelEnabler(leveler cakelog.Leveler) ZapLevelEnabler {
+	return ZapLevelEnabler{leveler: leveler}
 }
 
-// Ensures that ZapLogger implements the cakelog.Logger interface.
-var _ cakelog.Logger = (*ZapLogger)(nil)
+// Reports whether the zap level is at or above the current level of the leveler.
+func (zle ZapLevelEnabler) Enabled(level zapcore.Level) bool {
+	return level >= zle.Level()
+}
+
+// Returns the zap level closest to the current level of the leveler, so zap.Logger.Level reports it.
+func (zle ZapLevelEnabler) Level() zapcore.Level {
+	return ZapLevel(zle.leveler.Level())
+}
+
+// Maps a cakelog.Level to the closest zap level, as ZapLogger does for every message.
+// Zap has no trace level, so LevelTrace maps to the level just below zap.DebugLevel.
+// LevelPanic maps to zap.DPanicLevel, which is the panic level zap uses for messages that should not stop production.
+func ZapLevel(level cakelog.Level) zapcore.Level {
+	switch {
+	case level < cakelog.LevelDebug:
+		return zapcore.DebugLevel - 1
+	case level < cakelog.LevelInfo:
+		return zapcore.DebugLevel
+	case level < cakelog.LevelWarn:
+		return zapcore.InfoLevel
+	case level < cakelog.LevelError:
+		return zapcore.WarnLevel
+	case level < cakelog.LevelFatal:
+		return zapcore.ErrorLevel
+	case level < cakelog.LevelPanic:
+		return zapcore.FatalLevel
+	default:
+		return zapcore.DPanicLevel
+	}
+}
+
+var (
+	// Ensures that zapErrorChain implements the zapcore.ArrayMarshaler interface.
+	_ zapcore.ArrayMarshaler = zapErrorChain{}
+
+	// Ensures that zapErrorDetail implements the zapcore.ObjectMarshaler interface.
+	_ zapcore.ObjectMarshaler = zapErrorDetail{}
+
+	// Ensures that ZapLevelEnabler implements the zapcore.LevelEnabler interface.
+	_ zapcore.LevelEnabler = ZapLevelEnabler{}
+
+	// Ensures that ZapLogger implements the cakelog.Logger interface.
+	_ cakelog.Logger = (*ZapLogger)(nil)
+
+	// Ensures that ZapLogger implements the cakelog.LevelLogger interface.
+	_ cakelog.LevelLogger = (*ZapLogger)(nil)
+
+	// Ensures that ZapLogger implements the cakelog.Handler interface.
+	_ cakelog.Handler = (*ZapLogger)(nil)
+
+	// Ensures that ZapLogger implements the cakelog.WithLogger interface.
+	_ cakelog.WithLogger = (*ZapLogger)(nil)
+
+	// Ensures that ZapLogger implements the cakelog.Enabler interface.
+	_ cakelog.Enabler = (*ZapLogger)(nil)
+
+	// Ensures that ZapLogger implements the cakelog.GroupLogger interface.
+	_ cakelog.GroupLogger = (*ZapLogger)(nil)
+
+	// Ensures that ZapLogger implements the cakelog.Syncer interface.
+	_ cakelog.Syncer = (*ZapLogger)(nil)
+)
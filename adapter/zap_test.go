@@ -1,18 +1,26 @@
 package adapter_test
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/yuppyweb/cakelog"
 	"github.com/yuppyweb/cakelog/adapter"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
 
 type mockZapCore struct {
-	entry  zapcore.Entry
-	fields []zapcore.Field
+	entry   zapcore.Entry
+	fields  []zapcore.Field
+	syncErr error
 }
 
 func (c *mockZapCore) Enabled(zapcore.Level) bool {
@@ -37,7 +45,24 @@ func (c *mockZapCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
 }
 
 func (c *mockZapCore) Sync() error {
-	return nil
+	return c.syncErr
+}
+
+func zapObjectFields(t *testing.T, field zapcore.Field) map[string]any {
+	t.Helper()
+
+	marshaler, ok := field.Interface.(zapcore.ObjectMarshaler)
+	if !ok {
+		t.Fatalf("unexpected field type: got %T, want zapcore.ObjectMarshaler", field.Interface)
+	}
+
+	enc := zapcore.NewMapObjectEncoder()
+
+	if err := marshaler.MarshalLogObject(enc); err != nil {
+		t.Fatalf("failed to marshal field %q: %v", field.Key, err)
+	}
+
+	return enc.Fields
 }
 
 func TestZapLogger_DebugWithDefaultArgsKey(t *testing.T) {
@@ -48,6 +73,7 @@ func TestZapLogger_DebugWithDefaultArgsKey(t *testing.T) {
 
 	log := zap.New(mockCore)
 	logger := adapter.NewZapLogger(log)
+	logger.ArgsKey = adapter.LegacyArgsKey
 
 	logger.Debug(context.Background(), "debug message", values...)
 
@@ -56,7 +82,7 @@ func TestZapLogger_DebugWithDefaultArgsKey(t *testing.T) {
 	}
 
 	expectedFields := []zapcore.Field{
-		zap.Any(adapter.DefaultZapArgsKey, values),
+		zap.Any(adapter.LegacyArgsKey, values),
 	}
 
 	if len(mockCore.fields) != len(expectedFields) {
@@ -78,18 +104,17 @@ func TestZapLogger_DebugWithDefaultArgsKey(t *testing.T) {
 		}
 	}
 
-	fieldValue, ok := mockCore.fields[0].Interface.([]any)
-	if !ok {
-		t.Errorf("unexpected field type: got %T, want []any", mockCore.fields[0].Interface)
-	}
+	fieldValue := zapObjectFields(t, mockCore.fields[0])
+
+	for idx := 0; idx < len(values); idx += 2 {
+		key := fmt.Sprint(values[idx])
 
-	for idx, val := range values {
-		if fieldValue[idx] != val {
+		if fmt.Sprint(fieldValue[key]) != fmt.Sprint(values[idx+1]) {
 			t.Errorf(
-				"unexpected field value at index %d: got %v, want %v",
-				idx,
-				fieldValue[idx],
-				val,
+				"unexpected field value for key %q: got %v, want %v",
+				key,
+				fieldValue[key],
+				values[idx+1],
 			)
 		}
 	}
@@ -103,6 +128,7 @@ func TestZapLogger_InfoWithDefaultArgsKey(t *testing.T) {
 
 	log := zap.New(mockCore)
 	logger := adapter.NewZapLogger(log)
+	logger.ArgsKey = adapter.LegacyArgsKey
 
 	logger.Info(context.Background(), "info message", values...)
 
@@ -111,7 +137,7 @@ func TestZapLogger_InfoWithDefaultArgsKey(t *testing.T) {
 	}
 
 	expectedFields := []zapcore.Field{
-		zap.Any(adapter.DefaultZapArgsKey, values),
+		zap.Any(adapter.LegacyArgsKey, values),
 	}
 
 	if len(mockCore.fields) != len(expectedFields) {
@@ -133,18 +159,17 @@ func TestZapLogger_InfoWithDefaultArgsKey(t *testing.T) {
 		}
 	}
 
-	fieldValue, ok := mockCore.fields[0].Interface.([]any)
-	if !ok {
-		t.Errorf("unexpected field type: got %T, want []any", mockCore.fields[0].Interface)
-	}
+	fieldValue := zapObjectFields(t, mockCore.fields[0])
 
-	for idx, val := range values {
-		if fieldValue[idx] != val {
+	for idx := 0; idx < len(values); idx += 2 {
+		key := fmt.Sprint(values[idx])
+
+		if fmt.Sprint(fieldValue[key]) != fmt.Sprint(values[idx+1]) {
 			t.Errorf(
-				"unexpected field value at index %d: got %v, want %v",
-				idx,
-				fieldValue[idx],
-				val,
+				"unexpected field value for key %q: got %v, want %v",
+				key,
+				fieldValue[key],
+				values[idx+1],
 			)
 		}
 	}
@@ -158,6 +183,7 @@ func TestZapLogger_WarnWithDefaultArgsKey(t *testing.T) {
 
 	log := zap.New(mockCore)
 	logger := adapter.NewZapLogger(log)
+	logger.ArgsKey = adapter.LegacyArgsKey
 
 	logger.Warn(context.Background(), "warn message", values...)
 
@@ -166,7 +192,7 @@ func TestZapLogger_WarnWithDefaultArgsKey(t *testing.T) {
 	}
 
 	expectedFields := []zapcore.Field{
-		zap.Any(adapter.DefaultZapArgsKey, values),
+		zap.Any(adapter.LegacyArgsKey, values),
 	}
 
 	if len(mockCore.fields) != len(expectedFields) {
@@ -188,18 +214,17 @@ func TestZapLogger_WarnWithDefaultArgsKey(t *testing.T) {
 		}
 	}
 
-	fieldValue, ok := mockCore.fields[0].Interface.([]any)
-	if !ok {
-		t.Errorf("unexpected field type: got %T, want []any", mockCore.fields[0].Interface)
-	}
+	fieldValue := zapObjectFields(t, mockCore.fields[0])
+
+	for idx := 0; idx < len(values); idx += 2 {
+		key := fmt.Sprint(values[idx])
 
-	for idx, val := range values {
-		if fieldValue[idx] != val {
+		if fmt.Sprint(fieldValue[key]) != fmt.Sprint(values[idx+1]) {
 			t.Errorf(
-				"unexpected field value at index %d: got %v, want %v",
-				idx,
-				fieldValue[idx],
-				val,
+				"unexpected field value for key %q: got %v, want %v",
+				key,
+				fieldValue[key],
+				values[idx+1],
 			)
 		}
 	}
@@ -214,6 +239,7 @@ func TestZapLogger_ErrorWithDefaultArgsKey(t *testing.T) {
 
 	log := zap.New(mockCore)
 	logger := adapter.NewZapLogger(log)
+	logger.ArgsKey = adapter.LegacyArgsKey
 
 	logger.Error(context.Background(), expectedErr, values...)
 
@@ -221,8 +247,13 @@ func TestZapLogger_ErrorWithDefaultArgsKey(t *testing.T) {
 		t.Errorf("unexpected log level: got %v, want %v", mockCore.entry.Level, zap.ErrorLevel)
 	}
 
+	if mockCore.entry.Message != expectedErr.Error() {
+		t.Errorf("unexpected message: got %q, want %q", mockCore.entry.Message, expectedErr.Error())
+	}
+
 	expectedFields := []zapcore.Field{
-		zap.Any(adapter.DefaultZapArgsKey, values),
+		zap.Error(expectedErr),
+		zap.Any(adapter.LegacyArgsKey, values),
 	}
 
 	if len(mockCore.fields) != len(expectedFields) {
@@ -285,18 +316,17 @@ func TestZapLogger_DebugWithCustomArgsKey(t *testing.T) {
 		}
 	}
 
-	fieldValue, ok := mockCore.fields[0].Interface.([]any)
-	if !ok {
-		t.Errorf("unexpected field type: got %T, want []any", mockCore.fields[0].Interface)
-	}
+	fieldValue := zapObjectFields(t, mockCore.fields[0])
+
+	for idx := 0; idx < len(values); idx += 2 {
+		key := fmt.Sprint(values[idx])
 
-	for idx, val := range values {
-		if fieldValue[idx] != val {
+		if fmt.Sprint(fieldValue[key]) != fmt.Sprint(values[idx+1]) {
 			t.Errorf(
-				"unexpected field value at index %d: got %v, want %v",
-				idx,
-				fieldValue[idx],
-				val,
+				"unexpected field value for key %q: got %v, want %v",
+				key,
+				fieldValue[key],
+				values[idx+1],
 			)
 		}
 	}
@@ -342,18 +372,17 @@ func TestZapLogger_InfoWithCustomArgsKey(t *testing.T) {
 		}
 	}
 
-	fieldValue, ok := mockCore.fields[0].Interface.([]any)
-	if !ok {
-		t.Errorf("unexpected field type: got %T, want []any", mockCore.fields[0].Interface)
-	}
+	fieldValue := zapObjectFields(t, mockCore.fields[0])
 
-	for idx, val := range values {
-		if fieldValue[idx] != val {
+	for idx := 0; idx < len(values); idx += 2 {
+		key := fmt.Sprint(values[idx])
+
+		if fmt.Sprint(fieldValue[key]) != fmt.Sprint(values[idx+1]) {
 			t.Errorf(
-				"unexpected field value at index %d: got %v, want %v",
-				idx,
-				fieldValue[idx],
-				val,
+				"unexpected field value for key %q: got %v, want %v",
+				key,
+				fieldValue[key],
+				values[idx+1],
 			)
 		}
 	}
@@ -399,18 +428,17 @@ func TestZapLogger_WarnWithCustomArgsKey(t *testing.T) {
 		}
 	}
 
-	fieldValue, ok := mockCore.fields[0].Interface.([]any)
-	if !ok {
-		t.Errorf("unexpected field type: got %T, want []any", mockCore.fields[0].Interface)
-	}
+	fieldValue := zapObjectFields(t, mockCore.fields[0])
+
+	for idx := 0; idx < len(values); idx += 2 {
+		key := fmt.Sprint(values[idx])
 
-	for idx, val := range values {
-		if fieldValue[idx] != val {
+		if fmt.Sprint(fieldValue[key]) != fmt.Sprint(values[idx+1]) {
 			t.Errorf(
-				"unexpected field value at index %d: got %v, want %v",
-				idx,
-				fieldValue[idx],
-				val,
+				"unexpected field value for key %q: got %v, want %v",
+				key,
+				fieldValue[key],
+				values[idx+1],
 			)
 		}
 	}
@@ -434,7 +462,12 @@ func TestZapLogger_ErrorWithCustomArgsKey(t *testing.T) {
 		t.Errorf("unexpected log level: got %v, want %v", mockCore.entry.Level, zap.ErrorLevel)
 	}
 
+	if mockCore.entry.Message != expectedErr.Error() {
+		t.Errorf("unexpected message: got %q, want %q", mockCore.entry.Message, expectedErr.Error())
+	}
+
 	expectedFields := []zapcore.Field{
+		zap.Error(expectedErr),
 		zap.Any(customKey, values),
 	}
 
@@ -457,19 +490,515 @@ func TestZapLogger_ErrorWithCustomArgsKey(t *testing.T) {
 		}
 	}
 
-	fieldValue, ok := mockCore.fields[0].Interface.([]any)
-	if !ok {
-		t.Errorf("unexpected field type: got %T, want []any", mockCore.fields[0].Interface)
+	if mockCore.fields[0].Interface != expectedErr {
+		t.Errorf("unexpected error field: got %v, want %v", mockCore.fields[0].Interface, expectedErr)
 	}
 
-	for idx, val := range values {
-		if fieldValue[idx] != val {
+	fieldValue := zapObjectFields(t, mockCore.fields[1])
+
+	for idx := 0; idx < len(values); idx += 2 {
+		key := fmt.Sprint(values[idx])
+
+		if fmt.Sprint(fieldValue[key]) != fmt.Sprint(values[idx+1]) {
 			t.Errorf(
-				"unexpected field value at index %d: got %v, want %v",
-				idx,
-				fieldValue[idx],
-				val,
+				"unexpected field value for key %q: got %v, want %v",
+				key,
+				fieldValue[key],
+				values[idx+1],
 			)
 		}
 	}
 }
+
+func TestZapLogger_LogLevels(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		level    cakelog.Level
+		expected zapcore.Level
+	}{
+		{cakelog.LevelTrace, zap.DebugLevel - 1},
+		{cakelog.LevelDebug - 1, zap.DebugLevel - 1},
+		{cakelog.LevelDebug, zap.DebugLevel},
+		{cakelog.LevelInfo, zap.InfoLevel},
+		{cakelog.LevelWarn + 2, zap.WarnLevel},
+		{cakelog.LevelError, zap.ErrorLevel},
+		{cakelog.LevelFatal, zap.FatalLevel},
+		{cakelog.LevelPanic, zap.DPanicLevel},
+	}
+
+	for _, test := range tests {
+		mockCore := new(mockZapCore)
+		logger := adapter.NewZapLogger(zap.New(mockCore, zap.Development()))
+
+		logger.Log(context.Background(), test.level, "log message", nil)
+
+		if mockCore.entry.Level != test.expected {
+			t.Errorf("unexpected log level: got %v, want %v", mockCore.entry.Level, test.expected)
+		}
+
+		if mockCore.entry.Message != "log message" {
+			t.Errorf("unexpected message: got %q, want %q", mockCore.entry.Message, "log message")
+		}
+	}
+}
+
+func TestZapLogger_NormalizedArgs(t *testing.T) {
+	t.Parallel()
+
+	mockCore := new(mockZapCore)
+	logger := adapter.NewZapLogger(zap.New(mockCore))
+	logger.ArgsKey = adapter.LegacyArgsKey
+
+	logger.Info(
+		context.Background(),
+		"info message",
+		"user", 42,
+		cakelog.NewGroup("req", "method", "GET"),
+	)
+
+	if len(mockCore.fields) != 1 {
+		t.Fatalf("unexpected number of fields: got %d, want 1", len(mockCore.fields))
+	}
+
+	fields := zapObjectFields(t, mockCore.fields[0])
+
+	if fields["user"] != int64(42) {
+		t.Errorf("unexpected value for key %q: got %v, want 42", "user", fields["user"])
+	}
+
+	req, ok := fields["req"].(map[string]any)
+	if !ok || req["method"] != "GET" {
+		t.Errorf("unexpected value for key %q: got %v, want map[method:GET]", "req", fields["req"])
+	}
+}
+
+func TestZapLogger_WithAndWithGroup(t *testing.T) {
+	t.Parallel()
+
+	buf := &bytes.Buffer{}
+	core := zapcore.NewCore(
+		zapcore.NewJSONEncoder(zapcore.EncoderConfig{MessageKey: "msg"}),
+		zapcore.AddSync(buf),
+		zap.DebugLevel,
+	)
+
+	logger := cakelog.WithGroup(cakelog.With(adapter.NewZapLogger(zap.New(core)), "service", "api"), "http")
+
+	if _, ok := logger.(*adapter.ZapLogger); !ok {
+		t.Fatalf("expected native *adapter.ZapLogger, got %T", logger)
+	}
+
+	logger.Info(context.Background(), "info message", "status", 200)
+
+	expected := `{"msg":"info message","service":"api","http":{"status":200}}` + "\n"
+
+	if buf.String() != expected {
+		t.Errorf("unexpected log output:\nGot:  %s\nWant: %s", buf.String(), expected)
+	}
+}
+
+func TestZapLogger_Enabled(t *testing.T) {
+	t.Parallel()
+
+	core := zapcore.NewCore(
+		zapcore.NewJSONEncoder(zapcore.EncoderConfig{}),
+		zapcore.AddSync(&bytes.Buffer{}),
+		zap.InfoLevel,
+	)
+	logger := adapter.NewZapLogger(zap.New(core))
+
+	if logger.Enabled(context.Background(), cakelog.LevelDebug) {
+		t.Error("expected debug to be disabled")
+	}
+
+	if !logger.Enabled(context.Background(), cakelog.LevelInfo) {
+		t.Error("expected info to be enabled")
+	}
+}
+
+func TestZapLogger_Sync(t *testing.T) {
+	t.Parallel()
+
+	expectedErr := errors.New("sync failed")
+	logger := adapter.NewZapLogger(zap.New(&mockZapCore{syncErr: expectedErr}))
+
+	if err := cakelog.Shutdown(context.Background(), logger); !errors.Is(err, expectedErr) {
+		t.Errorf("expected error %v, got %v", expectedErr, err)
+	}
+}
+
+func TestZapLogger_Handle(t *testing.T) {
+	t.Parallel()
+
+	core := &mockZapCore{}
+	sugar := cakelog.NewSugar(adapter.NewZapLogger(zap.New(core, zap.AddCaller())))
+
+	sugar.Warnf(context.Background(), "user %d not found", 42)
+
+	if core.entry.Message != "user 42 not found" || core.entry.Level != zap.WarnLevel {
+		t.Errorf("unexpected entry: %v %q", core.entry.Level, core.entry.Message)
+	}
+
+	if !core.entry.Caller.Defined || !strings.HasSuffix(core.entry.Caller.Function, "TestZapLogger_Handle") {
+		t.Errorf("expected the caller to be TestZapLogger_Handle, got %q", core.entry.Caller.Function)
+	}
+
+	if !strings.HasSuffix(core.entry.Caller.File, "zap_test.go") {
+		t.Errorf("expected the caller file to be zap_test.go, got %q", core.entry.Caller.File)
+	}
+
+	if len(core.fields) != 1 {
+		t.Fatalf("expected 1 field, got %d", len(core.fields))
+	}
+
+	expected := zap.String(cakelog.DefaultFormatKey, "user %d not found")
+
+	if !core.fields[0].Equals(expected) {
+		t.Errorf("unexpected field:\nGot:  %v\nWant: %v", core.fields[0], expected)
+	}
+}
+
+func TestZapLogger_Caller(t *testing.T) {
+	t.Parallel()
+
+	buf := &bytes.Buffer{}
+	core := zapcore.NewCore(
+		zapcore.NewJSONEncoder(zapcore.EncoderConfig{
+			MessageKey:   "msg",
+			CallerKey:    "caller",
+			EncodeCaller: zapcore.FullCallerEncoder,
+		}),
+		zapcore.AddSync(buf),
+		zap.DebugLevel,
+	)
+	logger := adapter.NewZapLogger(zap.New(core, zap.AddCaller()))
+	ctx := context.Background()
+
+	_, file, line, _ := runtime.Caller(0)
+
+	logger.Debug(ctx, "debug message")
+	logger.Info(ctx, "info message")
+	logger.Warn(ctx, "warn message")
+	logger.Error(ctx, errors.New("error message"))
+	logger.Log(ctx, cakelog.LevelError, "log message", nil)
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 5 {
+		t.Fatalf("expected 5 entries, got %q", lines)
+	}
+
+	for idx, entry := range lines {
+		caller := fmt.Sprintf(`"caller":"%s:%d"`, file, line+2+idx)
+
+		if !strings.Contains(entry, caller) {
+			t.Errorf("expected the entry to contain %s, got %s", caller, entry)
+		}
+	}
+}
+
+func TestZapLogger_LazyArgs(t *testing.T) {
+	t.Parallel()
+
+	buf := &bytes.Buffer{}
+	core := zapcore.NewCore(
+		zapcore.NewJSONEncoder(zapcore.EncoderConfig{MessageKey: "msg"}),
+		zapcore.AddSync(buf),
+		zap.InfoLevel,
+	)
+	logger := adapter.NewZapLogger(zap.New(core))
+	calls := 0
+	body := cakelog.LazyField("body", func() any {
+		calls++
+
+		return []cakelog.Field{cakelog.NewField("size", 3)}
+	})
+
+	logger.Debug(context.Background(), "debug message", body)
+
+	if calls != 0 {
+		t.Fatalf("expected the lazy value not to be resolved for a disabled level, got %d calls", calls)
+	}
+
+	logger.Info(context.Background(), "info message", body)
+
+	expected := `{"msg":"info message","body":{"size":3}}` + "\n"
+
+	if buf.String() != expected {
+		t.Errorf("unexpected log output:\nGot:  %s\nWant: %s", buf.String(), expected)
+	}
+
+	if calls != 1 {
+		t.Errorf("expected the lazy value to be resolved once, got %d calls", calls)
+	}
+}
+
+func TestZapLevelEnabler(t *testing.T) {
+	t.Parallel()
+
+	levelVar := cakelog.NewLevelVar(cakelog.LevelInfo)
+	enabler := adapter.NewZapLevelEnabler(levelVar)
+	log := zap.New(zapcore.NewCore(
+		zapcore.NewJSONEncoder(zapcore.EncoderConfig{}),
+		zapcore.AddSync(io.Discard),
+		enabler,
+	))
+	logger := adapter.NewZapLogger(log)
+
+	if logger.Enabled(context.Background(), cakelog.LevelDebug) || log.Level() != zap.InfoLevel {
+		t.Errorf("expected debug to be disabled, got level %v", log.Level())
+	}
+
+	levelVar.Set(cakelog.LevelDebug)
+
+	if !logger.Enabled(context.Background(), cakelog.LevelDebug) || log.Level() != zap.DebugLevel {
+		t.Errorf("expected debug to be enabled after changing the level, got level %v", log.Level())
+	}
+}
+
+type zapUser struct {
+	id   int
+	name string
+}
+
+func (u zapUser) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddInt("id", u.id)
+	enc.AddString("name", u.name)
+
+	return nil
+}
+
+func TestZapLogger_TypedFields(t *testing.T) {
+	t.Parallel()
+
+	mockCore := new(mockZapCore)
+	logger := adapter.NewZapLogger(zap.New(mockCore))
+
+	logger.Info(
+		context.Background(),
+		"info message",
+		"name", "alice",
+		"attempt", 3,
+		"size", uint64(512),
+		"ratio", 0.5,
+		"ok", true,
+		"elapsed", 1500*time.Millisecond,
+		"at", time.Unix(1700000000, 0),
+		"cause", errors.New("timeout"),
+		"user", zapUser{id: 7, name: "bob"},
+		cakelog.NewGroup("req", "method", "GET"),
+		"tags", []string{"a", "b"},
+	)
+
+	expected := []zapcore.FieldType{
+		zapcore.StringType,
+		zapcore.Int64Type,
+		zapcore.Uint64Type,
+		zapcore.Float64Type,
+		zapcore.BoolType,
+		zapcore.DurationType,
+		zapcore.TimeType,
+		zapcore.ErrorType,
+		zapcore.ObjectMarshalerType,
+		zapcore.ObjectMarshalerType,
+		zapcore.ArrayMarshalerType,
+	}
+
+	if len(mockCore.fields) != len(expected) {
+		t.Fatalf("unexpected number of fields: got %d, want %d", len(mockCore.fields), len(expected))
+	}
+
+	for idx, fieldType := range expected {
+		if mockCore.fields[idx].Type != fieldType {
+			t.Errorf(
+				"unexpected type of field %q: got %v, want %v",
+				mockCore.fields[idx].Key,
+				mockCore.fields[idx].Type,
+				fieldType,
+			)
+		}
+	}
+}
+
+func TestZapLogger_TopLevelFields(t *testing.T) {
+	t.Parallel()
+
+	buf := &bytes.Buffer{}
+	core := zapcore.NewCore(
+		zapcore.NewJSONEncoder(zapcore.EncoderConfig{
+			MessageKey:     "msg",
+			EncodeDuration: zapcore.StringDurationEncoder,
+		}),
+		zapcore.AddSync(buf),
+		zap.InfoLevel,
+	)
+	entry := `{"msg":"query failed","error":"query failed","elapsed":"2s",` +
+		`"user":{"id":7,"name":"bob"},"req":{"method":"GET"}}` + "\n"
+
+	for _, pool := range []bool{false, true} {
+		buf.Reset()
+
+		logger := adapter.NewZapLogger(zap.New(core))
+		logger.PoolFields = pool
+
+		for range 3 {
+			logger.Error(
+				context.Background(),
+				errors.New("query failed"),
+				"elapsed", 2*time.Second,
+				"user", zapUser{id: 7, name: "bob"},
+				cakelog.NewGroup("req", "method", "GET"),
+			)
+		}
+
+		if expected := strings.Repeat(entry, 3); buf.String() != expected {
+			t.Errorf("unexpected log output with pooled fields %t:\nGot:  %s\nWant: %s", pool, buf.String(), expected)
+		}
+	}
+}
+
+func TestZapLogger_FieldsKeptByCore(t *testing.T) {
+	t.Parallel()
+
+	mockCore := new(mockZapCore)
+	logger := adapter.NewZapLogger(zap.New(mockCore))
+
+	logger.Info(context.Background(), "first message", "user", 42)
+	kept := mockCore.fields
+
+	logger.Info(context.Background(), "second message", "user", 7)
+
+	if len(kept) != 1 || !kept[0].Equals(zap.Int("user", 42)) {
+		t.Errorf("expected the core to keep the fields of the first entry, got %v", kept)
+	}
+}
+
+func newZapBenchmarkLogger() *zap.Logger {
+	return zap.New(zapcore.NewCore(
+		zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()),
+		zapcore.AddSync(io.Discard),
+		zap.InfoLevel,
+	))
+}
+
+func BenchmarkZapLogger(b *testing.B) {
+	logger := adapter.NewZapLogger(newZapBenchmarkLogger())
+	ctx := context.Background()
+
+	b.ReportAllocs()
+
+	for b.Loop() {
+		logger.Info(ctx, "request handled", "path", "/users", "status", 200, "elapsed", time.Millisecond)
+	}
+}
+
+func BenchmarkZapLogger_ArgsKey(b *testing.B) {
+	logger := adapter.NewZapLogger(newZapBenchmarkLogger())
+	logger.ArgsKey = adapter.LegacyArgsKey
+	ctx := context.Background()
+
+	b.ReportAllocs()
+
+	for b.Loop() {
+		logger.Info(ctx, "request handled", "path", "/users", "status", 200, "elapsed", time.Millisecond)
+	}
+}
+
+func BenchmarkZapLogger_PoolFields(b *testing.B) {
+	logger := adapter.NewZapLogger(newZapBenchmarkLogger())
+	logger.PoolFields = true
+	ctx := context.Background()
+
+	b.ReportAllocs()
+
+	for b.Loop() {
+		logger.Info(ctx, "request handled", "path", "/users", "status", 200, "elapsed", time.Millisecond)
+	}
+}
+
+func BenchmarkZapLogger_Disabled(b *testing.B) {
+	logger := adapter.NewZapLogger(newZapBenchmarkLogger())
+	ctx := context.Background()
+
+	b.ReportAllocs()
+
+	for b.Loop() {
+		logger.Debug(ctx, "request handled", "path", "/users", "status", 200, "elapsed", time.Millisecond)
+	}
+}
+
+func BenchmarkZap(b *testing.B) {
+	logger := newZapBenchmarkLogger()
+
+	b.ReportAllocs()
+
+	for b.Loop() {
+		logger.Info(
+			"request handled",
+			zap.String("path", "/users"),
+			zap.Int("status", 200),
+			zap.Duration("elapsed", time.Millisecond),
+		)
+	}
+}
+
+type zapTraceKey struct{}
+
+type zapRequestIDKey struct{}
+
+type zapTrace struct {
+	traceID string
+	spanID  string
+}
+
+func TestZapLogger_ContextExtractors(t *testing.T) {
+	t.Parallel()
+
+	buf := &bytes.Buffer{}
+	core := zapcore.NewCore(
+		zapcore.NewJSONEncoder(zapcore.EncoderConfig{MessageKey: "msg"}),
+		zapcore.AddSync(buf),
+		zap.InfoLevel,
+	)
+	calls := 0
+	logger := adapter.NewZapLogger(zap.New(core))
+	logger.ContextExtractors = []adapter.ZapContextExtractor{
+		func(ctx context.Context, fields []zap.Field) []zap.Field {
+			calls++
+
+			trace, ok := ctx.Value(zapTraceKey{}).(zapTrace)
+			if !ok {
+				return fields
+			}
+
+			return append(fields, zap.String("traceId", trace.traceID), zap.String("spanId", trace.spanID))
+		},
+		adapter.ZapContextValue("requestId", zapRequestIDKey{}),
+	}
+
+	ctx := context.WithValue(context.Background(), zapTraceKey{}, zapTrace{traceID: "4bf92f", spanID: "00f067"})
+	ctx = context.WithValue(ctx, zapRequestIDKey{}, "req-1")
+
+	logger.Debug(ctx, "debug message")
+
+	if calls != 0 {
+		t.Fatalf("expected the extractors not to be called for a disabled level, got %d calls", calls)
+	}
+
+	logger.Error(ctx, errors.New("query failed"), "user", 42)
+	cakelog.With(logger, "service", "api").Info(context.Background(), "info message")
+	cakelog.NewSugar(logger).Infof(ctx, "user %d", 7)
+
+	expected := `{"msg":"query failed","error":"query failed","traceId":"4bf92f","spanId":"00f067",` +
+		`"requestId":"req-1","user":42}` + "\n" +
+		`{"msg":"info message","service":"api"}` + "\n" +
+		`{"msg":"user 7","traceId":"4bf92f","spanId":"00f067","requestId":"req-1","format":"user %d"}` + "\n"
+
+	if buf.String() != expected {
+		t.Errorf("unexpected log output:\nGot:  %s\nWant: %s", buf.String(), expected)
+	}
+
+	if calls != 3 {
+		t.Errorf("expected the extractors to be called once per entry, got %d calls", calls)
+	}
+}
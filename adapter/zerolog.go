@@ -2,50 +2,257 @@ package adapter
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/rs/zerolog"
 	"github.com/yuppyweb/cakelog"
 )
 
-// Is the default key under which context arguments will be stored in zerolog entries.
-const DefaultZerologArgsKey = "context"
+// Is the key under which ZerologLogger nested the arguments before they became top-level fields.
+//
+// Deprecated: Use LegacyArgsKey.
+const DefaultZerologArgsKey = LegacyArgsKey
 
 // Is an adapter that allows using a zerolog.Logger as a cakelog.Logger.
 type ZerologLogger struct {
 	// The underlying zerolog.Logger to which log messages will be forwarded.
 	logger *zerolog.Logger
 
-	// The key under which the context arguments will be stored in zerolog entries.
+	// The key of a dictionary under which the arguments are nested, as a compatibility mode.
+	// If empty, which is the default, the arguments are written as top-level fields.
 	ArgsKey string
+
+	// The fields bound with With that hold lazy values, which are computed for every entry that is written.
+	lazy []cakelog.Field
 }
 
 // Creates a new ZerologLogger that wraps the provided zerolog.Logger.
 func NewZerologLogger(logger *zerolog.Logger) *ZerologLogger {
-	return &ZerologLogger{
-		logger:  logger,
-		ArgsKey: DefaultZerologArgsKey,
-	}
+	return &ZerologLogger{logger: logger}
 }
 
 // Sends a debug message to the underlying zerolog.Logger with the provided context and arguments.
 func (zl *ZerologLogger) Debug(ctx context.Context, msg string, args ...any) {
-	zl.logger.Debug().Ctx(ctx).Fields(map[string]any{zl.ArgsKey: args}).Msg(msg)
+	zl.Log(ctx, cakelog.LevelDebug, msg, nil, args...)
 }
 
 // Sends an info message to the underlying zerolog.Logger with the provided context and arguments.
 func (zl *ZerologLogger) Info(ctx context.Context, msg string, args ...any) {
-	zl.logger.Info().Ctx(ctx).Fields(map[string]any{zl.ArgsKey: args}).Msg(msg)
+	zl.Log(ctx, cakelog.LevelInfo, msg, nil, args...)
 }
 
 // Sends a warning message to the underlying zerolog.Logger with the provided context and arguments.
 func (zl *ZerologLogger) Warn(ctx context.Context, msg string, args ...any) {
-	zl.logger.Warn().Ctx(ctx).Fields(map[string]any{zl.ArgsKey: args}).Msg(msg)
+	zl.Log(ctx, cakelog.LevelWarn, msg, nil, args...)
 }
 
 // Sends an error message to the underlying zerolog.Logger with the provided context, error, and arguments.
 func (zl *ZerologLogger) Error(ctx context.Context, err error, args ...any) {
-	zl.logger.Error().Ctx(ctx).Fields(map[string]any{zl.ArgsKey: args}).Msg(err.Error())
+	zl.Log(ctx, cakelog.LevelError, "", err, args...)
+}
+
+// Sends a message at the given level to the underlying zerolog.Logger with the provided context, error, and arguments.
+// The error is written with zerolog.Event.Err, and its text is used if the message is empty.
+func (zl *ZerologLogger) Log(ctx context.Context, level cakelog.Level, msg string, err error, args ...any) {
+	event := zl.logger.WithLevel(ZerologLevel(level))
+	if event == nil {
+		return
+	}
+
+	err = entryError(err)
+
+	event = event.Ctx(ctx).Err(err)
+
+	if cakelog.HasErrorChain(err) {
+		event = event.Array(cakelog.ErrorChainKey, zerologErrorChain(cakelog.ErrorChain(err)))
+	}
+
+	event = appendZerologFields(event, cakelog.RenewLazy(zl.lazy))
+
+	if zl.ArgsKey == "" {
+		event = appendZerologArgs(event, args)
+	} else {
+		event = event.Dict(zl.ArgsKey, appendZerologArgs(zerolog.Dict(), args))
+	}
+
+	event.Msg(entryMessage(msg, err))
+}
+
+// Reports whether the underlying zerolog.Logger and the zerolog global level would write a message at the given level.
+func (zl *ZerologLogger) Enabled(_ context.Context, level cakelog.Level) bool {
+	zlevel := ZerologLevel(level)
+
+	return zlevel >= zl.logger.GetLevel() && zlevel >= zerolog.GlobalLevel()
+}
+
+// Returns a ZerologLogger whose underlying zerolog.Logger has the given arguments bound in its zerolog.Context.
+// The bound arguments are encoded once and added at the top level of every entry, next to the ArgsKey dictionary.
+// Zerolog encodes bound fields right away, so arguments holding lazy values are kept apart instead
+// and computed for every entry that is written, before its arguments.
+func (zl *ZerologLogger) With(args ...any) cakelog.Logger {
+	fields, lazy := bindFields(zl.lazy, cakelog.Normalize(args...))
+	logger := zl.logger.With().EmbedObject(zerologFields(fields)).Logger()
+
+	return &ZerologLogger{
+		logger:  &logger,
+		ArgsKey: zl.ArgsKey,
+		lazy:    lazy,
+	}
+}
+
+// Helper function to convert fields to a zerolog dictionary, with nested fields becoming nested dictionaries
+// and lazy values resolved.
+func zerologDict(fields []cakelog.Field) *zerolog.Event {
+	return appendZerologFields(zerolog.Dict(), fields)
+}
+
+// Helper function to add the arguments of a log call to a zerolog event as typed fields, following the rules
+// of cakelog.Normalize. Key-value pairs and fields are added directly, which saves allocating a list of fields,
+// and the other arguments, such as slog attributes and maps, are normalized one by one.
+func appendZerologArgs(event *zerolog.Event, args []any) *zerolog.Event {
+	for idx := 0; idx < len(args); idx++ {
+		switch arg := args[idx].(type) {
+		case string:
+			if idx+1 < len(args) {
+				idx++
+				event = appendZerologField(event, arg, args[idx])
+
+				continue
+			}
+		case cakelog.Field:
+			event = appendZerologField(event, arg.Key, arg.Value)
+
+			continue
+		}
+
+		event = appendZerologFields(event, cakelog.Normalize(args[idx]))
+	}
+
+	return event
+}
+
+// Helper function to add fields to a zerolog event as typed fields.
+func appendZerologFields(event *zerolog.Event, fields []cakelog.Field) *zerolog.Event {
+	for _, field := range fields {
+		event = appendZerologField(event, field.Key, field.Value)
+	}
+
+	return event
+}
+
+// Helper function to add a value to a zerolog event as a typed field without reflection for the common types:
+// strings, integers, floats, booleans, durations, times, errors, fmt.Stringer values,
+// and zerolog.LogObjectMarshaler and zerolog.LogArrayMarshaler implementations. Lazy values are resolved,
+// groups become nested dictionaries, and other values fall back to zerolog.Event.Interface.
+//
+//nolint:cyclop,gocyclo // A flat type switch is the clearest way to map the types.
+func appendZerologField(event *zerolog.Event, key string, value any) *zerolog.Event {
+	switch value := cakelog.Resolve(value).(type) {
+	case string:
+		return event.Str(key, value)
+	case int:
+		return event.Int(key, value)
+	case int64:
+		return event.Int64(key, value)
+	case int32:
+		return event.Int32(key, value)
+	case uint:
+		return event.Uint(key, value)
+	case uint64:
+		return event.Uint64(key, value)
+	case float64:
+		return event.Float64(key, value)
+	case bool:
+		return event.Bool(key, value)
+	case time.Duration:
+		return event.Dur(key, value)
+	case time.Time:
+		return event.Time(key, value)
+	case error:
+		return event.AnErr(key, value)
+	case []cakelog.Field:
+		return event.Dict(key, zerologDict(value))
+	case zerolog.LogObjectMarshaler:
+		return event.Object(key, value)
+	case zerolog.LogArrayMarshaler:
+		return event.Array(key, value)
+	case fmt.Stringer:
+		return event.Stringer(key, value)
+	default:
+		return event.Interface(key, value)
+	}
+}
+
+// Are fields embedded by zerolog at the top level of an object, such as the context of a logger.
+type zerologFields []cakelog.Field
+
+// Implements zerolog.LogObjectMarshaler.
+func (zf zerologFields) MarshalZerologObject(event *zerolog.Event) {
+	appendZerologFields(event, zf)
+}
+
+// Is an error chain encoded by zerolog as an array of objects.
+type zerologErrorChain []cakelog.ErrorDetail
+
+// Implements zerolog.LogArrayMarshaler.
+func (zc zerologErrorChain) MarshalZerologArray(arr *zerolog.Array) {
+	for _, detail := range zc {
+		arr.Object(zerologErrorDetail(detail))
+	}
 }
 
-// Ensures that ZerologLogger implements the cakelog.Logger interface.
-var _ cakelog.Logger = (*ZerologLogger)(nil)
+// Is a single error of a chain encoded by zerolog as an object with its message, type and fields.
+type zerologErrorDetail cakelog.ErrorDetail
+
+// Implements zerolog.LogObjectMarshaler.
+func (zd zerologErrorDetail) MarshalZerologObject(event *zerolog.Event) {
+	event.Str("message", zd.Message).Str("type", zd.Type)
+
+	if len(zd.Fields) > 0 {
+		event.Dict("fields", zerologDict(zd.Fields))
+	}
+}
+
+// Maps a cakelog.Level to the closest zerolog level, as ZerologLogger does for every message.
+func ZerologLevel(level cakelog.Level) zerolog.Level {
+	switch {
+	case level < cakelog.LevelDebug:
+		return zerolog.TraceLevel
+	case level < cakelog.LevelInfo:
+		return zerolog.DebugLevel
+	case level < cakelog.LevelWarn:
+		return zerolog.InfoLevel
+	case level < cakelog.LevelError:
+		return zerolog.WarnLevel
+	case level < cakelog.LevelFatal:
+		return zerolog.ErrorLevel
+	case level < cakelog.LevelPanic:
+		return zerolog.FatalLevel
+	default:
+		return zerolog.PanicLevel
+	}
+}
+
+var (
+	// Ensures that zerologErrorChain implements the zerolog.LogArrayMarshaler interface.
+	_ zerolog.LogArrayMarshaler = zerologErrorChain{}
+
+	// Ensures that zerologErrorDetail implements the zerolog.LogObjectMarshaler interface.
+	_ zerolog.LogObjectMarshaler = zerologErrorDetail{}
+
+	// Ensures that zerologFields implements the zerolog.LogObjectMarshaler interface.
+	_ zerolog.LogObjectMarshaler = zerologFields{}
+
+	// Ensures that ZerologLogger implements the cakelog.Logger interface.
+	_ cakelog.Logger = (*ZerologLogger)(nil)
+
+	// Ensures that ZerologLogger implements the cakelog.LevelLogger interface.
+	_ cakelog.LevelLogger = (*ZerologLogger)(nil)
+
+	// Ensures that ZerologLogger implements the cakelog.WithLogger interface.
+	_ cakelog.WithLogger = (*ZerologLogger)(nil)
+
+	// Ensures that ZerologLogger implements the cakelog.Enabler interface.
+	_ cakelog.Enabler = (*ZerologLogger)(nil)
+)
@@ -6,9 +6,12 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"testing"
+	"time"
 
 	"github.com/rs/zerolog"
+	"github.com/yuppyweb/cakelog"
 	"github.com/yuppyweb/cakelog/adapter"
 )
 
@@ -19,6 +22,7 @@ func TestZerologLogger_DebugWithDefaultArgsKey(t *testing.T) {
 	log := zerolog.New(buf)
 
 	logger := adapter.NewZerologLogger(&log)
+	logger.ArgsKey = adapter.LegacyArgsKey
 
 	logger.Debug(context.Background(), "debug message", "debug", 42)
 
@@ -28,8 +32,8 @@ func TestZerologLogger_DebugWithDefaultArgsKey(t *testing.T) {
 	}
 
 	expected := fmt.Sprintf(
-		`{"level":"debug","%s":[%q,%d],"message":"debug message"}`+"\n",
-		adapter.DefaultZerologArgsKey, "debug", 42,
+		`{"level":"debug","%s":{%q:%d},"message":"debug message"}`+"\n",
+		adapter.LegacyArgsKey, "debug", 42,
 	)
 
 	if string(output) != expected {
@@ -44,6 +48,7 @@ func TestZerologLogger_InfoWithDefaultArgsKey(t *testing.T) {
 	log := zerolog.New(buf)
 
 	logger := adapter.NewZerologLogger(&log)
+	logger.ArgsKey = adapter.LegacyArgsKey
 
 	logger.Info(context.Background(), "info message", "info", 65)
 
@@ -53,8 +58,8 @@ func TestZerologLogger_InfoWithDefaultArgsKey(t *testing.T) {
 	}
 
 	expected := fmt.Sprintf(
-		`{"level":"info","%s":[%q,%d],"message":"info message"}`+"\n",
-		adapter.DefaultZerologArgsKey, "info", 65,
+		`{"level":"info","%s":{%q:%d},"message":"info message"}`+"\n",
+		adapter.LegacyArgsKey, "info", 65,
 	)
 
 	if string(output) != expected {
@@ -69,6 +74,7 @@ func TestZerologLogger_WarnWithDefaultArgsKey(t *testing.T) {
 	log := zerolog.New(buf)
 
 	logger := adapter.NewZerologLogger(&log)
+	logger.ArgsKey = adapter.LegacyArgsKey
 
 	logger.Warn(context.Background(), "warn message", "warn", 80)
 
@@ -78,8 +84,8 @@ func TestZerologLogger_WarnWithDefaultArgsKey(t *testing.T) {
 	}
 
 	expected := fmt.Sprintf(
-		`{"level":"warn","%s":[%q,%d],"message":"warn message"}`+"\n",
-		adapter.DefaultZerologArgsKey, "warn", 80,
+		`{"level":"warn","%s":{%q:%d},"message":"warn message"}`+"\n",
+		adapter.LegacyArgsKey, "warn", 80,
 	)
 
 	if string(output) != expected {
@@ -94,6 +100,7 @@ func TestZerologLogger_ErrorWithDefaultArgsKey(t *testing.T) {
 	log := zerolog.New(buf)
 
 	logger := adapter.NewZerologLogger(&log)
+	logger.ArgsKey = adapter.LegacyArgsKey
 
 	err := errors.New("test error")
 	logger.Error(context.Background(), err, "error", 99)
@@ -104,8 +111,8 @@ func TestZerologLogger_ErrorWithDefaultArgsKey(t *testing.T) {
 	}
 
 	expected := fmt.Sprintf(
-		`{"level":"error","%s":[%q,%d],"message":"test error"}`+"\n",
-		adapter.DefaultZerologArgsKey, "error", 99,
+		`{"level":"error","error":"test error","%s":{%q:%d},"message":"test error"}`+"\n",
+		adapter.LegacyArgsKey, "error", 99,
 	)
 
 	if string(output) != expected {
@@ -130,7 +137,7 @@ func TestZerologLogger_DebugWithCustomArgsKey(t *testing.T) {
 	}
 
 	expected := fmt.Sprintf(
-		`{"level":"debug","%s":[%q,%d],"message":"debug message"}`+"\n",
+		`{"level":"debug","%s":{%q:%d},"message":"debug message"}`+"\n",
 		"customArgs1", "debug", 42,
 	)
 
@@ -156,7 +163,7 @@ func TestZerologLogger_InfoWithCustomArgsKey(t *testing.T) {
 	}
 
 	expected := fmt.Sprintf(
-		`{"level":"info","%s":[%q,%d],"message":"info message"}`+"\n",
+		`{"level":"info","%s":{%q:%d},"message":"info message"}`+"\n",
 		"customArgs2", "info", 65,
 	)
 
@@ -182,7 +189,7 @@ func TestZerologLogger_WarnWithCustomArgsKey(t *testing.T) {
 	}
 
 	expected := fmt.Sprintf(
-		`{"level":"warn","%s":[%q,%d],"message":"warn message"}`+"\n",
+		`{"level":"warn","%s":{%q:%d},"message":"warn message"}`+"\n",
 		"customArgs3", "warn", 80,
 	)
 
@@ -209,7 +216,7 @@ func TestZerologLogger_ErrorWithCustomArgsKey(t *testing.T) {
 	}
 
 	expected := fmt.Sprintf(
-		`{"level":"error","%s":[%q,%d],"message":"test error"}`+"\n",
+		`{"level":"error","error":"test error","%s":{%q:%d},"message":"test error"}`+"\n",
 		"customArgs4", "error", 99,
 	)
 
@@ -217,3 +224,246 @@ func TestZerologLogger_ErrorWithCustomArgsKey(t *testing.T) {
 		t.Errorf("unexpected log output:\nGot:  %s\nWant: %s", string(output), expected)
 	}
 }
+
+func TestZerologLogger_LogWithCustomLevel(t *testing.T) {
+	t.Parallel()
+
+	buf := &bytes.Buffer{}
+	log := zerolog.New(buf)
+
+	logger := adapter.NewZerologLogger(&log)
+
+	logger.Log(context.Background(), cakelog.LevelWarn+1, "log message", nil, "log", 7)
+
+	expected := `{"level":"warn","log":7,"message":"log message"}` + "\n"
+
+	if buf.String() != expected {
+		t.Errorf("unexpected log output:\nGot:  %s\nWant: %s", buf.String(), expected)
+	}
+}
+
+func TestZerologLogger_NormalizedArgs(t *testing.T) {
+	t.Parallel()
+
+	buf := &bytes.Buffer{}
+	log := zerolog.New(buf)
+
+	logger := adapter.NewZerologLogger(&log)
+
+	logger.Info(
+		context.Background(),
+		"info message",
+		"user", 42,
+		cakelog.NewGroup("req", "method", "GET"),
+		map[string]any{"cause": errors.New("timeout")},
+		slog.Group("db", slog.String("host", "localhost")),
+		[]cakelog.Field{cakelog.NewField("retry", true)},
+		7,
+		"orphan",
+	)
+
+	expected := `{"level":"info","user":42,"req":{"method":"GET"},"cause":"timeout","db":{"host":"localhost"},` +
+		`"retry":true,"!BADKEY":7,"!BADKEY":"orphan","message":"info message"}` + "\n"
+
+	if buf.String() != expected {
+		t.Errorf("unexpected log output:\nGot:  %s\nWant: %s", buf.String(), expected)
+	}
+}
+
+func TestZerologLogger_With(t *testing.T) {
+	t.Parallel()
+
+	buf := &bytes.Buffer{}
+	log := zerolog.New(buf)
+
+	logger := cakelog.With(adapter.NewZerologLogger(&log), "service", "api", cakelog.NewGroup("build", "version", 2))
+
+	if _, ok := logger.(*adapter.ZerologLogger); !ok {
+		t.Fatalf("expected native *adapter.ZerologLogger, got %T", logger)
+	}
+
+	logger.Info(context.Background(), "info message", "user", 42)
+
+	expected := `{"level":"info","service":"api","build":{"version":2},"user":42,` +
+		`"message":"info message"}` + "\n"
+
+	if buf.String() != expected {
+		t.Errorf("unexpected log output:\nGot:  %s\nWant: %s", buf.String(), expected)
+	}
+}
+
+func TestZerologLogger_Enabled(t *testing.T) {
+	t.Parallel()
+
+	log := zerolog.New(io.Discard).Level(zerolog.ErrorLevel)
+	logger := adapter.NewZerologLogger(&log)
+
+	if logger.Enabled(context.Background(), cakelog.LevelWarn) {
+		t.Error("expected warn to be disabled")
+	}
+
+	if !logger.Enabled(context.Background(), cakelog.LevelError) {
+		t.Error("expected error to be enabled")
+	}
+}
+
+func TestZerologLogger_LogTraceFatalAndPanic(t *testing.T) {
+	t.Parallel()
+
+	buf := &bytes.Buffer{}
+	log := zerolog.New(buf)
+
+	logger := adapter.NewZerologLogger(&log)
+
+	logger.Log(context.Background(), cakelog.LevelTrace, "trace message", nil)
+	logger.Log(context.Background(), cakelog.LevelFatal, "fatal message", nil)
+	logger.Log(context.Background(), cakelog.LevelPanic, "panic message", nil)
+
+	expected := `{"level":"trace","message":"trace message"}` + "\n" +
+		`{"level":"fatal","message":"fatal message"}` + "\n" +
+		`{"level":"panic","message":"panic message"}` + "\n"
+
+	if buf.String() != expected {
+		t.Errorf("unexpected log output:\nGot:  %s\nWant: %s", buf.String(), expected)
+	}
+}
+
+func TestZerologLogger_LazyArgs(t *testing.T) {
+	t.Parallel()
+
+	buf := &bytes.Buffer{}
+	log := zerolog.New(buf).Level(zerolog.InfoLevel)
+	logger := adapter.NewZerologLogger(&log)
+	calls := 0
+	body := cakelog.LazyField("body", func() any {
+		calls++
+
+		return "payload"
+	})
+
+	logger.Debug(context.Background(), "debug message", body)
+
+	if calls != 0 {
+		t.Fatalf("expected the lazy value not to be resolved for a disabled level, got %d calls", calls)
+	}
+
+	logger.Info(context.Background(), "info message", body)
+
+	expected := `{"level":"info","body":"payload","message":"info message"}` + "\n"
+
+	if buf.String() != expected {
+		t.Errorf("unexpected log output:\nGot:  %s\nWant: %s", buf.String(), expected)
+	}
+
+	if calls != 1 {
+		t.Errorf("expected the lazy value to be resolved once, got %d calls", calls)
+	}
+}
+
+type zerologUser struct {
+	id    int
+	name  string
+	calls *int
+}
+
+func (u zerologUser) MarshalZerologObject(event *zerolog.Event) {
+	*u.calls++
+
+	event.Int("id", u.id).Str("name", u.name)
+}
+
+func TestZerologLogger_TypedFields(t *testing.T) {
+	t.Parallel()
+
+	buf := &bytes.Buffer{}
+	log := zerolog.New(buf)
+	logger := adapter.NewZerologLogger(&log)
+	calls := 0
+
+	logger.Info(
+		context.Background(),
+		"info message",
+		"name", "alice",
+		"attempt", 3,
+		"ok", true,
+		"elapsed", 1500*time.Millisecond,
+		"cause", errors.New("timeout"),
+		"user", zerologUser{id: 7, name: "bob", calls: &calls},
+		cakelog.NewGroup("req", "method", "GET"),
+		"tags", []string{"a", "b"},
+	)
+
+	expected := `{"level":"info","name":"alice","attempt":3,"ok":true,"elapsed":1500,"cause":"timeout",` +
+		`"user":{"id":7,"name":"bob"},"req":{"method":"GET"},"tags":["a","b"],"message":"info message"}` + "\n"
+
+	if buf.String() != expected {
+		t.Errorf("unexpected log output:\nGot:  %s\nWant: %s", buf.String(), expected)
+	}
+
+	if calls != 1 {
+		t.Errorf("expected the object to be marshaled once, got %d calls", calls)
+	}
+}
+
+func TestZerologLogger_DisabledLevel(t *testing.T) {
+	t.Parallel()
+
+	buf := &bytes.Buffer{}
+	log := zerolog.New(buf).Level(zerolog.InfoLevel)
+	logger := adapter.NewZerologLogger(&log)
+	calls := 0
+
+	logger.Debug(context.Background(), "debug message", "user", zerologUser{id: 7, name: "bob", calls: &calls})
+	cakelog.Log(context.Background(), logger, cakelog.LevelTrace, "trace message", errors.New("ignored"))
+
+	if calls != 0 || buf.Len() != 0 {
+		t.Errorf("expected no output and no marshaling for disabled levels, got %d calls and %q", calls, buf.String())
+	}
+
+	logger.Info(context.Background(), "info message", "user", zerologUser{id: 7, name: "bob", calls: &calls})
+
+	expected := `{"level":"info","user":{"id":7,"name":"bob"},"message":"info message"}` + "\n"
+
+	if buf.String() != expected {
+		t.Errorf("unexpected log output:\nGot:  %s\nWant: %s", buf.String(), expected)
+	}
+}
+
+func BenchmarkZerologLogger(b *testing.B) {
+	log := zerolog.New(io.Discard)
+	logger := adapter.NewZerologLogger(&log)
+	ctx := context.Background()
+
+	b.ReportAllocs()
+
+	for b.Loop() {
+		logger.Info(ctx, "request handled", "path", "/users", "status", 200, "elapsed", time.Millisecond)
+	}
+}
+
+func BenchmarkZerologLogger_ArgsKey(b *testing.B) {
+	log := zerolog.New(io.Discard)
+	logger := adapter.NewZerologLogger(&log)
+	logger.ArgsKey = adapter.LegacyArgsKey
+	ctx := context.Background()
+
+	b.ReportAllocs()
+
+	for b.Loop() {
+		logger.Info(ctx, "request handled", "path", "/users", "status", 200, "elapsed", time.Millisecond)
+	}
+}
+
+func BenchmarkZerolog(b *testing.B) {
+	log := zerolog.New(io.Discard)
+
+	b.ReportAllocs()
+
+	for b.Loop() {
+		log.Info().
+			Str("path", "/users").
+			Int("status", 200).
+			Dur("elapsed", time.Millisecond).
+			Msg("request handled")
+	}
+}
@@ -0,0 +1,82 @@
+package cakelogtest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/yuppyweb/cakelog"
+)
+
+// Checks that the recorder has a record matching all of the given matchers, and returns the first one.
+// Otherwise it reports an error listing the records and returns false.
+func AssertLogged(tb testing.TB, recorder *Recorder, matchers ...Matcher) (cakelog.Record, bool) {
+	tb.Helper()
+
+	found := recorder.Filter(matchers...)
+	if len(found) == 0 {
+		tb.Errorf("expected a record with %s, got:\n%s", describe(matchers), formatRecords(recorder.Records()))
+
+		return cakelog.Record{}, false
+	}
+
+	return found[0], true
+}
+
+// Checks that the recorder has no record matching all of the given matchers.
+func AssertNotLogged(tb testing.TB, recorder *Recorder, matchers ...Matcher) bool {
+	tb.Helper()
+
+	found := recorder.Filter(matchers...)
+	if len(found) > 0 {
+		tb.Errorf("expected no record with %s, got:\n%s", describe(matchers), formatRecords(found))
+
+		return false
+	}
+
+	return true
+}
+
+// Checks that the recorder has exactly the given number of records matching all of the given matchers.
+func AssertCount(tb testing.TB, recorder *Recorder, count int, matchers ...Matcher) bool {
+	tb.Helper()
+
+	found := recorder.Filter(matchers...)
+	if len(found) != count {
+		tb.Errorf("expected %d records with %s, got %d:\n%s",
+			count, describe(matchers), len(found), formatRecords(recorder.Records()))
+
+		return false
+	}
+
+	return true
+}
+
+// Helper function to describe the matchers in a failure message.
+func describe(matchers []Matcher) string {
+	if len(matchers) == 0 {
+		return "anything"
+	}
+
+	descs := make([]string, 0, len(matchers))
+
+	for _, matcher := range matchers {
+		descs = append(descs, matcher.String())
+	}
+
+	return strings.Join(descs, " and ")
+}
+
+// Helper function to list the records in a failure message, one per line.
+func formatRecords(records []cakelog.Record) string {
+	if len(records) == 0 {
+		return "\t(no records)"
+	}
+
+	lines := make([]string, 0, len(records))
+
+	for _, record := range records {
+		lines = append(lines, "\t"+formatRecord(record))
+	}
+
+	return strings.Join(lines, "\n")
+}
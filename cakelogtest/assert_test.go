@@ -0,0 +1,89 @@
+package cakelogtest_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/yuppyweb/cakelog"
+	"github.com/yuppyweb/cakelog/cakelogtest"
+)
+
+type fakeTB struct {
+	testing.TB
+
+	errors []string
+	logs   []string
+}
+
+func (*fakeTB) Helper() {}
+
+func (ft *fakeTB) Errorf(format string, args ...any) {
+	ft.errors = append(ft.errors, fmt.Sprintf(format, args...))
+}
+
+func (ft *fakeTB) Log(args ...any) {
+	ft.logs = append(ft.logs, fmt.Sprint(args...))
+}
+
+func (*fakeTB) Cleanup(func()) {}
+
+func TestAssertLogged(t *testing.T) {
+	t.Parallel()
+
+	recorder := cakelogtest.NewRecorder()
+	recorder.Info(context.Background(), "started", "port", 8080)
+
+	record, ok := cakelogtest.AssertLogged(t, recorder, cakelogtest.Message("started"), cakelogtest.Attr("port", 8080))
+	if !ok || record.Level != cakelog.LevelInfo {
+		t.Errorf("expected the info record, got %+v", record)
+	}
+
+	tb := new(fakeTB)
+
+	if _, ok := cakelogtest.AssertLogged(tb, recorder, cakelogtest.Level(cakelog.LevelWarn)); ok {
+		t.Error("expected the assertion to fail")
+	}
+
+	if len(tb.errors) != 1 || !strings.Contains(tb.errors[0], "expected a record with level warn") ||
+		!strings.Contains(tb.errors[0], "info started port=8080") {
+		t.Errorf("expected a failure listing the records, got %q", tb.errors)
+	}
+}
+
+func TestAssertNotLogged(t *testing.T) {
+	t.Parallel()
+
+	recorder := cakelogtest.NewRecorder()
+	recorder.Debug(context.Background(), "cache miss")
+
+	if !cakelogtest.AssertNotLogged(t, recorder, cakelogtest.Level(cakelog.LevelError)) {
+		t.Error("expected the assertion to pass")
+	}
+
+	tb := new(fakeTB)
+
+	if cakelogtest.AssertNotLogged(tb, recorder, cakelogtest.MessageContains("cache")) || len(tb.errors) != 1 {
+		t.Errorf("expected the assertion to fail, got %q", tb.errors)
+	}
+}
+
+func TestAssertCount(t *testing.T) {
+	t.Parallel()
+
+	recorder := cakelogtest.NewRecorder()
+	recorder.Warn(context.Background(), "retry")
+	recorder.Warn(context.Background(), "retry")
+
+	if !cakelogtest.AssertCount(t, recorder, 2, cakelogtest.Message("retry")) {
+		t.Error("expected the assertion to pass")
+	}
+
+	tb := new(fakeTB)
+
+	if cakelogtest.AssertCount(tb, recorder, 1) || len(tb.errors) != 1 ||
+		!strings.Contains(tb.errors[0], "expected 1 records with anything, got 2") {
+		t.Errorf("expected the assertion to fail, got %q", tb.errors)
+	}
+}
@@ -0,0 +1,140 @@
+package cakelogtest
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/yuppyweb/cakelog"
+)
+
+// Is a condition on a cakelog.Record, with a description used in the failure messages of the Assert functions.
+type Matcher interface {
+	// Reports whether the record satisfies the condition.
+	Match(record cakelog.Record) bool
+
+	// Describes the condition.
+	String() string
+}
+
+// Is a Matcher built from a function and a description.
+type matcherFunc struct {
+	// Reports whether the record satisfies the condition.
+	match func(record cakelog.Record) bool
+
+	// Describes the condition.
+	desc string
+}
+
+// Reports whether the record satisfies the condition.
+func (mf matcherFunc) Match(record cakelog.Record) bool {
+	return mf.match(record)
+}
+
+// Describes the condition.
+func (mf matcherFunc) String() string {
+	return mf.desc
+}
+
+// Creates a Matcher from a function and a description, for conditions not covered by this package.
+func MatcherFunc(desc string, match func(record cakelog.Record) bool) Matcher {
+	return matcherFunc{match: match, desc: desc}
+}
+
+// Reports whether the record matches all of the given matchers. No matchers match every record.
+func Match(record cakelog.Record, matchers ...Matcher) bool {
+	for _, matcher := range matchers {
+		if !matcher.Match(record) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Matches the records at the given level.
+func Level(level cakelog.Level) Matcher {
+	return MatcherFunc("level "+level.String(), func(record cakelog.Record) bool {
+		return record.Level == level
+	})
+}
+
+// Matches the records with exactly the given message.
+func Message(msg string) Matcher {
+	return MatcherFunc(fmt.Sprintf("message %q", msg), func(record cakelog.Record) bool {
+		return record.Message == msg
+	})
+}
+
+// Matches the records whose message contains the given text.
+func MessageContains(text string) Matcher {
+	return MatcherFunc(fmt.Sprintf("message containing %q", text), func(record cakelog.Record) bool {
+		return strings.Contains(record.Message, text)
+	})
+}
+
+// Matches the records whose error is or wraps the target, as reported by errors.Is.
+// A nil target matches the records without an error, including those with a typed nil pointer error.
+func Error(target error) Matcher {
+	return MatcherFunc(fmt.Sprintf("error %v", target), func(record cakelog.Record) bool {
+		if target == nil {
+			return cakelog.IsNilError(record.Err)
+		}
+
+		return errors.Is(record.Err, target)
+	})
+}
+
+// Matches the records with an attribute with the given key and a value deeply equal to the given one.
+// Lazy values are resolved before comparing, and the attributes of groups are reached with dotted keys
+// such as "request.path".
+func Attr(key string, value any) Matcher {
+	return MatcherFunc(fmt.Sprintf("attribute %s=%v", key, value), func(record cakelog.Record) bool {
+		found, ok := Lookup(record, key)
+
+		return ok && reflect.DeepEqual(found, value)
+	})
+}
+
+// Matches the records with an attribute with the given key, whatever its value.
+func HasAttr(key string) Matcher {
+	return MatcherFunc("attribute "+key, func(record cakelog.Record) bool {
+		_, ok := Lookup(record, key)
+
+		return ok
+	})
+}
+
+// Returns the resolved value of the attribute of the record with the given key, with dotted keys reaching
+// into groups as for Attr. If several attributes have the key, the last one is returned, as most backends do.
+func Lookup(record cakelog.Record, key string) (any, bool) {
+	return lookup(record.Attrs, key)
+}
+
+// Helper function to find the value of a key in the fields, descending into groups for dotted keys.
+func lookup(fields []cakelog.Field, key string) (any, bool) {
+	var (
+		found any
+		ok    bool
+	)
+
+	for _, field := range fields {
+		if field.Key == key {
+			found, ok = cakelog.Resolve(field.Value), true
+
+			continue
+		}
+
+		group, isGroup := field.Value.([]cakelog.Field)
+		if !isGroup || !strings.HasPrefix(key, field.Key+".") {
+			continue
+		}
+
+		if value, inGroup := lookup(group, key[len(field.Key)+1:]); inGroup {
+			found, ok = value, true
+		}
+	}
+
+	return found, ok
+}
@@ -0,0 +1,87 @@
+package cakelogtest_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/yuppyweb/cakelog"
+	"github.com/yuppyweb/cakelog/cakelogtest"
+)
+
+func TestMatchers(t *testing.T) {
+	t.Parallel()
+
+	errNotFound := errors.New("not found")
+	record := cakelog.NewRecord(
+		context.Background(), cakelog.LevelWarn, "user lookup failed", fmt.Errorf("lookup: %w", errNotFound),
+		"user", 42, cakelog.NewGroup("request", "path", "/users", "tags", []string{"a"}),
+		cakelog.LazyField("lazy", func() any { return "resolved" }),
+	)
+
+	matching := []cakelogtest.Matcher{
+		cakelogtest.Level(cakelog.LevelWarn),
+		cakelogtest.Message("user lookup failed"),
+		cakelogtest.MessageContains("lookup"),
+		cakelogtest.Error(errNotFound),
+		cakelogtest.Attr("user", 42),
+		cakelogtest.Attr("request.path", "/users"),
+		cakelogtest.Attr("request.tags", []string{"a"}),
+		cakelogtest.Attr("lazy", "resolved"),
+		cakelogtest.HasAttr("request"),
+	}
+
+	for _, matcher := range matching {
+		if !matcher.Match(record) {
+			t.Errorf("expected the record to match %s", matcher)
+		}
+	}
+
+	if !cakelogtest.Match(record, matching...) {
+		t.Error("expected the record to match all matchers")
+	}
+
+	notMatching := []cakelogtest.Matcher{
+		cakelogtest.Level(cakelog.LevelInfo),
+		cakelogtest.Message("user lookup"),
+		cakelogtest.MessageContains("timeout"),
+		cakelogtest.Error(errors.New("not found")),
+		cakelogtest.Error(nil),
+		cakelogtest.Attr("user", "42"),
+		cakelogtest.Attr("request.method", nil),
+		cakelogtest.HasAttr("path"),
+	}
+
+	for _, matcher := range notMatching {
+		if matcher.Match(record) {
+			t.Errorf("expected the record not to match %s", matcher)
+		}
+	}
+}
+
+func TestMatcherFunc(t *testing.T) {
+	t.Parallel()
+
+	matcher := cakelogtest.MatcherFunc("has a program counter", func(record cakelog.Record) bool {
+		return record.PC != 0
+	})
+
+	if matcher.String() != "has a program counter" || matcher.Match(cakelog.Record{}) {
+		t.Errorf("unexpected matcher %s", matcher)
+	}
+}
+
+func TestLookup(t *testing.T) {
+	t.Parallel()
+
+	record := cakelog.NewRecord(context.Background(), cakelog.LevelInfo, "", nil, "key", 1, "key", 2, "nil", nil)
+
+	if value, ok := cakelogtest.Lookup(record, "key"); !ok || value != 2 {
+		t.Errorf("expected the last value, got %v %v", value, ok)
+	}
+
+	if value, ok := cakelogtest.Lookup(record, "nil"); !ok || value != nil {
+		t.Errorf("expected a nil value to be found, got %v %v", value, ok)
+	}
+}
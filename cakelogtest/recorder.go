@@ -0,0 +1,145 @@
+// Package cakelogtest provides loggers and assertions for testing code that logs through cakelog.
+//
+// A Recorder keeps every message as a cakelog.Record, which the Matcher values of this package select
+// and the Assert functions check. A TestLogger writes every message through testing.TB.Log,
+// so the output of a logger is attached to the test or subtest that created it.
+package cakelogtest
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/yuppyweb/cakelog"
+)
+
+// Recorder is a cakelog.Logger that keeps every message as a cakelog.Record.
+// It is safe for concurrent use, and loggers derived from it with cakelog.With and cakelog.WithGroup
+// record into it with their arguments.
+type Recorder struct {
+	// Provides the logging methods, which pass every message to Handle as a cakelog.Record.
+	*cakelog.HandlerLogger
+
+	// Guards the records and the lifecycle counters.
+	mu sync.Mutex
+
+	// The records in the order they were logged.
+	records []cakelog.Record
+
+	// The number of calls to Sync.
+	syncs int
+
+	// The number of calls to Close.
+	closes int
+}
+
+// Creates a new empty Recorder.
+func NewRecorder() *Recorder {
+	rec := new(Recorder)
+	rec.HandlerLogger = cakelog.NewHandlerLogger(rec)
+
+	return rec
+}
+
+// Keeps the record.
+func (r *Recorder) Handle(record cakelog.Record) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.records = append(r.records, record)
+}
+
+// Reports that messages at every level are recorded.
+func (*Recorder) Enabled(context.Context, cakelog.Level) bool {
+	return true
+}
+
+// Counts the call, which can be checked with Syncs.
+func (r *Recorder) Sync(context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.syncs++
+
+	return nil
+}
+
+// Counts the call, which can be checked with Closes. The recorder keeps recording after it is closed.
+func (r *Recorder) Close(context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.closes++
+
+	return nil
+}
+
+// Returns a copy of the records, in the order they were logged.
+func (r *Recorder) Records() []cakelog.Record {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return slices.Clone(r.records)
+}
+
+// Returns the records that match all of the given matchers, in the order they were logged.
+func (r *Recorder) Filter(matchers ...Matcher) []cakelog.Record {
+	var found []cakelog.Record
+
+	for _, record := range r.Records() {
+		if Match(record, matchers...) {
+			found = append(found, record)
+		}
+	}
+
+	return found
+}
+
+// Returns the number of records.
+func (r *Recorder) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return len(r.records)
+}
+
+// Returns the number of calls to Sync.
+func (r *Recorder) Syncs() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.syncs
+}
+
+// Returns the number of calls to Close.
+func (r *Recorder) Closes() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.closes
+}
+
+// Discards the records, so a recorder can be reused between the steps of a test.
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.records = nil
+}
+
+var (
+	// Ensures that Recorder implements the cakelog.Logger interface.
+	_ cakelog.Logger = (*Recorder)(nil)
+
+	// Ensures that Recorder implements the cakelog.Handler interface.
+	_ cakelog.Handler = (*Recorder)(nil)
+
+	// Ensures that Recorder implements the cakelog.Enabler interface.
+	_ cakelog.Enabler = (*Recorder)(nil)
+
+	// Ensures that Recorder implements the cakelog.Syncer interface.
+	_ cakelog.Syncer = (*Recorder)(nil)
+
+	// Ensures that Recorder implements the cakelog.Closer interface.
+	_ cakelog.Closer = (*Recorder)(nil)
+)
@@ -0,0 +1,76 @@
+package cakelogtest_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/yuppyweb/cakelog"
+	"github.com/yuppyweb/cakelog/cakelogtest"
+)
+
+func TestRecorder(t *testing.T) {
+	t.Parallel()
+
+	recorder := cakelogtest.NewRecorder()
+	expectedErr := errors.New("failed")
+
+	recorder.Info(context.Background(), "started", "port", 8080)
+	cakelog.With(recorder, "request", 1).Warn(context.Background(), "slow")
+	recorder.Error(context.Background(), expectedErr)
+
+	records := recorder.Records()
+	if len(records) != 3 || recorder.Len() != 3 {
+		t.Fatalf("expected 3 records, got %d", len(records))
+	}
+
+	if records[1].Level != cakelog.LevelWarn || records[1].Attrs[0].Key != "request" {
+		t.Errorf("expected the warn record with the bound arguments, got %+v", records[1])
+	}
+
+	if records[2].Err != expectedErr {
+		t.Errorf("expected the error to be recorded, got %v", records[2].Err)
+	}
+
+	if found := recorder.Filter(cakelogtest.Level(cakelog.LevelInfo)); len(found) != 1 {
+		t.Errorf("expected 1 info record, got %d", len(found))
+	}
+
+	if err := cakelog.Shutdown(context.Background(), recorder); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+
+	if recorder.Syncs() != 1 || recorder.Closes() != 1 {
+		t.Errorf("expected 1 sync and 1 close, got %d and %d", recorder.Syncs(), recorder.Closes())
+	}
+
+	recorder.Reset()
+
+	if recorder.Len() != 0 {
+		t.Errorf("expected no records after a reset, got %d", recorder.Len())
+	}
+}
+
+func TestRecorder_Concurrent(t *testing.T) {
+	t.Parallel()
+
+	recorder := cakelogtest.NewRecorder()
+
+	var wg sync.WaitGroup
+
+	for range 10 {
+		wg.Go(func() {
+			for range 100 {
+				recorder.Debug(context.Background(), "message")
+				_ = recorder.Records()
+			}
+		})
+	}
+
+	wg.Wait()
+
+	if recorder.Len() != 1000 {
+		t.Errorf("expected 1000 records, got %d", recorder.Len())
+	}
+}
@@ -0,0 +1,149 @@
+package cakelogtest
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/yuppyweb/cakelog"
+)
+
+// TestLogger is a cakelog.Logger that writes every message through testing.TB.Log,
+// so the output appears with the test or subtest that created the logger, and only when it fails or runs verbosely.
+// Messages logged after the test has completed are dropped, since testing.TB.Log panics for them.
+type TestLogger struct {
+	// Provides the Sync, Close and Unwrap methods. The logging methods are defined by TestLogger itself,
+	// so every frame between the test and testing.TB.Log is marked as a helper.
+	*cakelog.HandlerLogger
+
+	// The test to which the messages are written.
+	tb testing.TB
+
+	// Set when the test has completed.
+	done *atomic.Bool
+}
+
+// Creates a new TestLogger that writes to the given test.
+func NewTestLogger(tb testing.TB) *TestLogger {
+	done := new(atomic.Bool)
+	tb.Cleanup(func() { done.Store(true) })
+
+	tl := &TestLogger{
+		tb:   tb,
+		done: done,
+	}
+	tl.HandlerLogger = cakelog.NewHandlerLogger(tl)
+
+	return tl
+}
+
+// Writes a debug message to the test, reported at the line of the caller.
+func (tl *TestLogger) Debug(ctx context.Context, msg string, args ...any) {
+	tl.tb.Helper()
+	tl.log(ctx, cakelog.LevelDebug, msg, nil, args)
+}
+
+// Writes an info message to the test, reported at the line of the caller.
+func (tl *TestLogger) Info(ctx context.Context, msg string, args ...any) {
+	tl.tb.Helper()
+	tl.log(ctx, cakelog.LevelInfo, msg, nil, args)
+}
+
+// Writes a warning message to the test, reported at the line of the caller.
+func (tl *TestLogger) Warn(ctx context.Context, msg string, args ...any) {
+	tl.tb.Helper()
+	tl.log(ctx, cakelog.LevelWarn, msg, nil, args)
+}
+
+// Writes an error message to the test, reported at the line of the caller.
+func (tl *TestLogger) Error(ctx context.Context, err error, args ...any) {
+	tl.tb.Helper()
+	tl.log(ctx, cakelog.LevelError, "", err, args)
+}
+
+// Writes a message at the given level to the test, reported at the line of the caller.
+func (tl *TestLogger) Log(ctx context.Context, level cakelog.Level, msg string, err error, args ...any) {
+	tl.tb.Helper()
+	tl.log(ctx, level, msg, err, args)
+}
+
+// Writes the record to the test as a single line with its level, message, error and attributes.
+func (tl *TestLogger) Handle(record cakelog.Record) {
+	if tl.done.Load() {
+		return
+	}
+
+	tl.tb.Helper()
+	tl.tb.Log(formatRecord(record))
+}
+
+// Reports whether messages would still be written to the test.
+func (tl *TestLogger) Enabled(context.Context, cakelog.Level) bool {
+	return !tl.done.Load()
+}
+
+// Helper method to build a record with the program counter of the caller of the logging method and to write it.
+func (tl *TestLogger) log(ctx context.Context, level cakelog.Level, msg string, err error, args []any) {
+	tl.tb.Helper()
+
+	record := cakelog.NewRecord(ctx, level, msg, err, args...)
+
+	var pcs [1]uintptr
+
+	// Skips runtime.Callers, this method and the logging method.
+	runtime.Callers(3, pcs[:])
+	record.PC = pcs[0]
+
+	tl.Handle(record)
+}
+
+// Helper function to format a record as its level, message, error and attributes in the logfmt style.
+// Groups are flattened into dotted keys.
+func formatRecord(record cakelog.Record) string {
+	var sb strings.Builder
+
+	sb.WriteString(record.Level.String())
+
+	if record.Message != "" {
+		sb.WriteString(" ")
+		sb.WriteString(record.Message)
+	}
+
+	if !cakelog.IsNilError(record.Err) {
+		fmt.Fprintf(&sb, " error=%q", record.Err.Error())
+	}
+
+	writeFields(&sb, "", record.Attrs)
+
+	return sb.String()
+}
+
+// Helper function to write the fields as key=value pairs, with the keys of groups prefixed by their name.
+func writeFields(sb *strings.Builder, prefix string, fields []cakelog.Field) {
+	for _, field := range fields {
+		if group, ok := field.Value.([]cakelog.Field); ok {
+			writeFields(sb, prefix+field.Key+".", group)
+
+			continue
+		}
+
+		fmt.Fprintf(sb, " %s%s=%v", prefix, field.Key, cakelog.Resolve(field.Value))
+	}
+}
+
+var (
+	// Ensures that TestLogger implements the cakelog.Logger interface.
+	_ cakelog.Logger = (*TestLogger)(nil)
+
+	// Ensures that TestLogger implements the cakelog.LevelLogger interface.
+	_ cakelog.LevelLogger = (*TestLogger)(nil)
+
+	// Ensures that TestLogger implements the cakelog.Handler interface.
+	_ cakelog.Handler = (*TestLogger)(nil)
+
+	// Ensures that TestLogger implements the cakelog.Enabler interface.
+	_ cakelog.Enabler = (*TestLogger)(nil)
+)
@@ -0,0 +1,144 @@
+package cakelogtest_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/yuppyweb/cakelog"
+	"github.com/yuppyweb/cakelog/cakelogtest"
+)
+
+func TestTestLogger(t *testing.T) {
+	t.Parallel()
+
+	tb := new(fakeTB)
+	logger := cakelogtest.NewTestLogger(tb)
+
+	logger.Info(context.Background(), "started", "port", 8080, cakelog.NewGroup("db", "host", "localhost"))
+	logger.Error(context.Background(), errors.New("failed"), "attempt", 2)
+
+	expected := []string{
+		"info started port=8080 db.host=localhost",
+		`error error="failed" attempt=2`,
+	}
+
+	if len(tb.logs) != len(expected) {
+		t.Fatalf("expected %d lines, got %q", len(expected), tb.logs)
+	}
+
+	for idx, line := range expected {
+		if tb.logs[idx] != line {
+			t.Errorf("expected %q, got %q", line, tb.logs[idx])
+		}
+	}
+
+	if !logger.Enabled(context.Background(), cakelog.LevelTrace) {
+		t.Error("expected every level to be enabled")
+	}
+}
+
+type locationTB struct {
+	testing.TB
+
+	helpers   map[string]bool
+	locations []string
+}
+
+func (lt *locationTB) Helper() {
+	pc, _, _, _ := runtime.Caller(1)
+	lt.helpers[runtime.FuncForPC(pc).Name()] = true
+}
+
+func (lt *locationTB) Log(...any) {
+	var pcs [16]uintptr
+
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs[:])])
+
+	for {
+		frame, more := frames.Next()
+		if !lt.helpers[frame.Function] || !more {
+			lt.locations = append(lt.locations, fmt.Sprintf("%s:%d", filepath.Base(frame.File), frame.Line))
+
+			return
+		}
+	}
+}
+
+func (*locationTB) Cleanup(func()) {}
+
+func TestTestLogger_Location(t *testing.T) {
+	t.Parallel()
+
+	tb := &locationTB{helpers: make(map[string]bool)}
+	logger := cakelogtest.NewTestLogger(tb)
+	ctx := context.Background()
+
+	_, file, line, _ := runtime.Caller(0)
+
+	logger.Debug(ctx, "debug message")
+	logger.Info(ctx, "info message")
+	logger.Warn(ctx, "warn message")
+	logger.Error(ctx, errors.New("error message"))
+	logger.Log(ctx, cakelog.LevelError, "log message", nil)
+
+	if len(tb.locations) != 5 {
+		t.Fatalf("expected 5 lines, got %q", tb.locations)
+	}
+
+	for idx, location := range tb.locations {
+		if expected := fmt.Sprintf("%s:%d", filepath.Base(file), line+2+idx); location != expected {
+			t.Errorf("expected the line to be reported at %s, got %s", expected, location)
+		}
+	}
+}
+
+type pointerError struct{}
+
+func (*pointerError) Error() string {
+	return "pointer error"
+}
+
+func TestTestLogger_TypedNilError(t *testing.T) {
+	t.Parallel()
+
+	tb := new(fakeTB)
+	logger := cakelogtest.NewTestLogger(tb)
+
+	var typedNil *pointerError
+
+	logger.Log(context.Background(), cakelog.LevelWarn, "degraded", typedNil, "attempt", 2)
+
+	if len(tb.logs) != 1 || tb.logs[0] != "warn degraded attempt=2" {
+		t.Errorf("expected the typed nil error to be omitted, got %q", tb.logs)
+	}
+
+	record := cakelog.NewRecord(context.Background(), cakelog.LevelWarn, "degraded", typedNil)
+	if !cakelogtest.Error(nil).Match(record) {
+		t.Error("expected the typed nil error to match a nil target")
+	}
+}
+
+func TestTestLogger_Subtest(t *testing.T) {
+	t.Parallel()
+
+	var logger *cakelogtest.TestLogger
+
+	t.Run("subtest", func(t *testing.T) {
+		t.Parallel()
+
+		logger = cakelogtest.NewTestLogger(t)
+		logger.Info(context.Background(), "attached to the subtest")
+	})
+
+	t.Cleanup(func() {
+		logger.Info(context.Background(), "dropped after the subtest completed")
+
+		if logger.Enabled(context.Background(), cakelog.LevelInfo) {
+			t.Error("expected the logger to be disabled after the subtest completed")
+		}
+	})
+}
@@ -0,0 +1,314 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rs/zerolog"
+	"github.com/sirupsen/logrus"
+	"github.com/yuppyweb/cakelog"
+	"github.com/yuppyweb/cakelog/adapter"
+	"github.com/yuppyweb/cakelog/decorator"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	// Is the default name of the counter created by the prometheus decorator.
+	DefaultPrometheusCounterName = "log_messages_total"
+
+	// Is the label of the counter created by the prometheus decorator that holds the level of the messages.
+	PrometheusLevelLabel = "level"
+)
+
+// Are the levels sent to Sentry by the sentry decorator if its options do not list any.
+//
+//nolint:gochecknoglobals // A slice cannot be a constant.
+var defaultSentryLevels = []string{"error", "fatal", "panic"}
+
+// Creates an adapter.SlogLogger. The formats are "json" (the default) and "text",
+// and the "addSource" option adds the source of the call to every entry.
+func newSlogAdapter(spec AdapterSpec) (cakelog.Logger, error) {
+	if err := spec.Options.Only("addSource"); err != nil {
+		return nil, err
+	}
+
+	addSource, err := spec.Options.Bool("addSource", false)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := &slog.HandlerOptions{
+		AddSource:   addSource,
+		Level:       slog.Level(spec.Level),
+		ReplaceAttr: adapter.SlogReplaceLevel,
+	}
+
+	var handler slog.Handler
+
+	switch spec.Format {
+	case "", "json":
+		handler = slog.NewJSONHandler(spec.Output, opts)
+	case "text":
+		handler = slog.NewTextHandler(spec.Output, opts)
+	default:
+		return nil, formatError(spec.Format, "json", "text")
+	}
+
+	logger := adapter.NewSlogLogger(slog.New(handler))
+
+	if spec.ArgsKey != nil {
+		logger.ArgsKey = *spec.ArgsKey
+	}
+
+	return logger, nil
+}
+
+// Creates an adapter.ZapLogger with the production encoder configuration. The formats are "json" (the default)
+// and "console", and the "addCaller" option adds the caller to every entry.
+func newZapAdapter(spec AdapterSpec) (cakelog.Logger, error) {
+	if err := spec.Options.Only("addCaller"); err != nil {
+		return nil, err
+	}
+
+	addCaller, err := spec.Options.Bool("addCaller", false)
+	if err != nil {
+		return nil, err
+	}
+
+	var encoder zapcore.Encoder
+
+	switch spec.Format {
+	case "", "json":
+		encoder = zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig())
+	case "console":
+		encoder = zapcore.NewConsoleEncoder(zap.NewProductionEncoderConfig())
+	default:
+		return nil, formatError(spec.Format, "json", "console")
+	}
+
+	core := zapcore.NewCore(encoder, zapcore.AddSync(spec.Output), adapter.ZapLevel(spec.Level))
+	logger := adapter.NewZapLogger(zap.New(core, zap.WithCaller(addCaller)))
+
+	if spec.ArgsKey != nil {
+		logger.ArgsKey = *spec.ArgsKey
+	}
+
+	return logger, nil
+}
+
+// Creates an adapter.ZerologLogger with timestamps. The formats are "json" (the default)
+// and "console", which uses zerolog.ConsoleWriter without colors.
+func newZerologAdapter(spec AdapterSpec) (cakelog.Logger, error) {
+	if err := spec.Options.Only(); err != nil {
+		return nil, err
+	}
+
+	output := spec.Output
+
+	switch spec.Format {
+	case "", "json":
+	case "console":
+		output = zerolog.ConsoleWriter{Out: spec.Output, NoColor: true}
+	default:
+		return nil, formatError(spec.Format, "json", "console")
+	}
+
+	log := zerolog.New(output).Level(adapter.ZerologLevel(spec.Level)).With().Timestamp().Logger()
+	logger := adapter.NewZerologLogger(&log)
+
+	if spec.ArgsKey != nil {
+		logger.ArgsKey = *spec.ArgsKey
+	}
+
+	return logger, nil
+}
+
+// Creates an adapter.LogrusLogger. The formats are "json" (the default) and "text",
+// and the "errorMessage" option sets the message of error entries logged without a message.
+func newLogrusAdapter(spec AdapterSpec) (cakelog.Logger, error) {
+	if err := spec.Options.Only("errorMessage"); err != nil {
+		return nil, err
+	}
+
+	errorMessage, err := spec.Options.String("errorMessage", "")
+	if err != nil {
+		return nil, err
+	}
+
+	log := logrus.New()
+	log.SetOutput(spec.Output)
+	log.SetLevel(adapter.LogrusLevel(spec.Level))
+
+	switch spec.Format {
+	case "", "json":
+		log.SetFormatter(&logrus.JSONFormatter{})
+	case "text":
+		log.SetFormatter(&logrus.TextFormatter{DisableColors: true})
+	default:
+		return nil, formatError(spec.Format, "json", "text")
+	}
+
+	logger := adapter.NewLogrusLogger(log)
+	logger.ErrorMessage = errorMessage
+
+	if spec.ArgsKey != nil {
+		logger.ArgsKey = *spec.ArgsKey
+	}
+
+	return logger, nil
+}
+
+// Wraps the logger with a decorator.ContextLogger, which has no options.
+func newContextDecorator(logger cakelog.Logger, options Options) (cakelog.Logger, error) {
+	if err := options.Only(); err != nil {
+		return nil, err
+	}
+
+	return decorator.NewContextLogger(logger), nil
+}
+
+// Wraps the logger with a decorator.PrometheusLogger counting messages in a counter vector labelled by level,
+// which is registered with prometheus.DefaultRegisterer. Building the same counter again reuses the registered one.
+// The options are "name", "namespace", "subsystem" and "help".
+func newPrometheusDecorator(logger cakelog.Logger, options Options) (cakelog.Logger, error) {
+	if err := options.Only("name", "namespace", "subsystem", "help"); err != nil {
+		return nil, err
+	}
+
+	var opts prometheus.CounterOpts
+
+	errs := []error{
+		stringOption(options, "name", DefaultPrometheusCounterName, &opts.Name),
+		stringOption(options, "namespace", "", &opts.Namespace),
+		stringOption(options, "subsystem", "", &opts.Subsystem),
+		stringOption(options, "help", "Number of log messages by level.", &opts.Help),
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	vec := prometheus.NewCounterVec(opts, []string{PrometheusLevelLabel})
+
+	if err := prometheus.DefaultRegisterer.Register(vec); err != nil {
+		var registeredErr prometheus.AlreadyRegisteredError
+		if !errors.As(err, &registeredErr) {
+			return nil, optionError("name", fmt.Errorf("%w: %w", ErrInvalidValue, err))
+		}
+
+		existing, ok := registeredErr.ExistingCollector.(*prometheus.CounterVec)
+		if !ok {
+			return nil, optionError("name", fmt.Errorf("%w: %w", ErrInvalidValue, err))
+		}
+
+		vec = existing
+	}
+
+	return decorator.NewPrometheusLogger(logger, decorator.PrometheusLoggerCounter{
+		Trace: vec.WithLabelValues("trace"),
+		Debug: vec.WithLabelValues("debug"),
+		Info:  vec.WithLabelValues("info"),
+		Warn:  vec.WithLabelValues("warn"),
+		Error: vec.WithLabelValues("error"),
+		Fatal: vec.WithLabelValues("fatal"),
+		Panic: vec.WithLabelValues("panic"),
+	}), nil
+}
+
+// Wraps the logger with a decorator.SentryLogger that sends the messages of the listed levels to Sentry.
+// The options are "levels" (error, fatal and panic by default), "eventIdKey",
+// and "dsn" and "environment" for a new client. Without a DSN the current hub of the process is used.
+func newSentryDecorator(logger cakelog.Logger, options Options) (cakelog.Logger, error) {
+	if err := options.Only("levels", "eventIdKey", "dsn", "environment"); err != nil {
+		return nil, err
+	}
+
+	var dsn, environment, eventIDKey string
+
+	errs := []error{
+		stringOption(options, "dsn", "", &dsn),
+		stringOption(options, "environment", "", &environment),
+		stringOption(options, "eventIdKey", decorator.DefaultSentryEventIDKey, &eventIDKey),
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	levels, err := options.Strings("levels", defaultSentryLevels)
+	if err != nil {
+		return nil, err
+	}
+
+	hub := sentry.CurrentHub()
+
+	if dsn != "" {
+		client, err := sentry.NewClient(sentry.ClientOptions{Dsn: dsn, Environment: environment})
+		if err != nil {
+			return nil, optionError("dsn", fmt.Errorf("%w: %w", ErrInvalidValue, err))
+		}
+
+		hub = sentry.NewHub(client, sentry.NewScope())
+	}
+
+	var hubs decorator.SentryLoggerHub
+
+	for idx, name := range levels {
+		if err := setSentryHub(&hubs, name, hub); err != nil {
+			return nil, optionError(fmt.Sprintf("levels[%d]", idx), err)
+		}
+	}
+
+	sentryLogger := decorator.NewSentryLogger(logger, hubs)
+	sentryLogger.SentryEventIDKey = eventIDKey
+
+	return sentryLogger, nil
+}
+
+// Helper function to read a string option into the target.
+func stringOption(options Options, key, fallback string, target *string) error {
+	value, err := options.String(key, fallback)
+	*target = value
+
+	return err
+}
+
+// Helper function to set the hub of the level with the given name.
+// Only the names of the levels defined by cakelog are accepted, since the hubs are per level.
+func setSentryHub(hubs *decorator.SentryLoggerHub, name string, hub *sentry.Hub) error {
+	level, err := cakelog.ParseLevel(name)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidValue, err)
+	}
+
+	switch level {
+	case cakelog.LevelTrace:
+		hubs.Trace = hub
+	case cakelog.LevelDebug:
+		hubs.Debug = hub
+	case cakelog.LevelInfo:
+		hubs.Info = hub
+	case cakelog.LevelWarn:
+		hubs.Warn = hub
+	case cakelog.LevelError:
+		hubs.Error = hub
+	case cakelog.LevelFatal:
+		hubs.Fatal = hub
+	case cakelog.LevelPanic:
+		hubs.Panic = hub
+	default:
+		return fmt.Errorf("%w: level %q has no Sentry hub", ErrInvalidValue, name)
+	}
+
+	return nil
+}
+
+// Helper function to report a format that the adapter does not support.
+func formatError(format string, supported ...string) error {
+	return &ValidationError{
+		Path: "format",
+		Err:  fmt.Errorf("%w: format %q is not one of %q", ErrInvalidValue, format, supported),
+	}
+}
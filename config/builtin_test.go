@@ -0,0 +1,266 @@
+package config_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/yuppyweb/cakelog"
+	"github.com/yuppyweb/cakelog/adapter"
+	"github.com/yuppyweb/cakelog/config"
+	"github.com/yuppyweb/cakelog/decorator"
+)
+
+func buildToFile(t *testing.T, cfg *config.Config) string {
+	t.Helper()
+
+	cfg.Adapter.Output = filepath.Join(t.TempDir(), "app.log")
+
+	logger, err := config.NewRegistry().Build(cfg)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	logger.Debug(context.Background(), "debug message")
+	logger.Warn(context.Background(), "warn message", "user", 42)
+
+	if err := cakelog.Shutdown(context.Background(), logger); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	data, err := os.ReadFile(cfg.Adapter.Output)
+	if err != nil {
+		t.Fatalf("failed to read log file: %v", err)
+	}
+
+	return string(data)
+}
+
+func counterValue(t *testing.T, name, level string) float64 {
+	t.Helper()
+
+	families, err := prometheus.DefaultGatherer.Gather()
+	if err != nil {
+		t.Fatalf("failed to gather metrics: %v", err)
+	}
+
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == config.PrometheusLevelLabel && label.GetValue() == level {
+					return metric.GetCounter().GetValue()
+				}
+			}
+		}
+	}
+
+	t.Fatalf("counter %s with level %s not found", name, level)
+
+	return 0
+}
+
+func TestBuiltinAdapters(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		adapter  config.AdapterConfig
+		expected []string
+	}{
+		{
+			adapter:  config.AdapterConfig{Type: "slog", Level: "info", Options: config.Options{"addSource": true}},
+			expected: []string{`"level":"WARN"`, `"msg":"warn message"`, `"user":42}`, `"source":`},
+		},
+		{
+			adapter:  config.AdapterConfig{Type: "slog", Format: "text", ArgsKey: new("args")},
+			expected: []string{"level=WARN", `msg="warn message"`, "args.user=42"},
+		},
+		{
+			adapter: config.AdapterConfig{Type: "zap", Options: config.Options{"addCaller": true}},
+			expected: []string{
+				`"level":"warn"`, `"msg":"warn message"`, `"user":42}`, `"caller":"config/builtin_test.go:29"`,
+			},
+		},
+		{
+			adapter:  config.AdapterConfig{Type: "zap", Format: "console", ArgsKey: new("args")},
+			expected: []string{"warn", "warn message", `{"args": {"user": 42}}`},
+		},
+		{
+			adapter:  config.AdapterConfig{Type: "zap", ArgsKey: new("context")},
+			expected: []string{`"msg":"warn message","context":{"user":42}}`},
+		},
+		{
+			adapter:  config.AdapterConfig{Type: "zerolog"},
+			expected: []string{`"level":"warn"`, `"message":"warn message"`, `"user":42`, `"time":`},
+		},
+		{
+			adapter:  config.AdapterConfig{Type: "zerolog", Format: "console"},
+			expected: []string{"WRN", "warn message"},
+		},
+		{
+			adapter:  config.AdapterConfig{Type: "logrus", ArgsKey: new("args")},
+			expected: []string{`"level":"warning"`, `"msg":"warn message"`, `"args":{"user":42}`},
+		},
+		{
+			adapter:  config.AdapterConfig{Type: "logrus", Format: "text"},
+			expected: []string{"level=warning", `msg="warn message"`, "user=42"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.adapter.Type+"/"+test.adapter.Format, func(t *testing.T) {
+			t.Parallel()
+
+			output := buildToFile(t, &config.Config{Adapter: test.adapter})
+
+			if strings.Contains(output, "debug message") || strings.Count(output, "\n") != 1 {
+				t.Errorf("expected only the warn message to be written, got %s", output)
+			}
+
+			for _, expected := range test.expected {
+				if !strings.Contains(output, expected) {
+					t.Errorf("expected the output to contain %s, got %s", expected, output)
+				}
+			}
+		})
+	}
+}
+
+func TestBuiltinAdapters_InvalidFormat(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range []string{"slog", "zap", "zerolog", "logrus"} {
+		_, err := config.NewRegistry().Build(&config.Config{
+			Adapter: config.AdapterConfig{Type: name, Output: "discard", Format: "xml"},
+		})
+
+		var validationErr *config.ValidationError
+		if !errors.As(err, &validationErr) || validationErr.Path != "adapter.format" {
+			t.Errorf("expected a validation error at adapter.format for %s, got %v", name, err)
+		}
+
+		_, err = config.NewRegistry().Build(&config.Config{
+			Adapter: config.AdapterConfig{Type: name, Output: "discard", Options: config.Options{"colour": true}},
+		})
+
+		if !errors.Is(err, config.ErrUnknownOption) {
+			t.Errorf("expected an unknown option error for %s, got %v", name, err)
+		}
+	}
+}
+
+func TestBuiltinAdapters_LogrusErrorMessage(t *testing.T) {
+	t.Parallel()
+
+	output := filepath.Join(t.TempDir(), "app.log")
+
+	logger, err := config.NewRegistry().Build(&config.Config{
+		Adapter: config.AdapterConfig{
+			Type:    "logrus",
+			Output:  output,
+			Format:  "text",
+			Options: config.Options{"errorMessage": "request failed"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	logger.Error(context.Background(), errors.New("connection refused"))
+
+	if err := cakelog.Shutdown(context.Background(), logger); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	data, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatalf("failed to read log file: %v", err)
+	}
+
+	if !strings.Contains(string(data), `msg="request failed" error="connection refused"`) {
+		t.Errorf("expected the configured message and the error, got %s", data)
+	}
+}
+
+func TestBuiltinDecorators(t *testing.T) {
+	t.Parallel()
+
+	logger, err := config.NewRegistry().Build(&config.Config{
+		Adapter: config.AdapterConfig{Type: "zap", Output: "discard"},
+		Decorators: []config.DecoratorConfig{
+			{Type: "context"},
+			{Type: "sentry", Options: config.Options{"levels": []any{"warn", "error"}, "eventIdKey": "eventId"}},
+			{Type: "prometheus", Options: config.Options{"name": "config_test_builtin_total", "namespace": "cakelog"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if _, ok := logger.(*decorator.ContextLogger); !ok {
+		t.Errorf("expected the context decorator to be the outermost, got %T", logger)
+	}
+
+	sentryLogger, ok := cakelog.Find[*decorator.SentryLogger](logger)
+	if !ok || sentryLogger.SentryEventIDKey != "eventId" {
+		t.Errorf("expected a sentry decorator with the configured event ID key, got %v", sentryLogger)
+	}
+
+	if _, ok := cakelog.Find[*adapter.ZapLogger](logger); !ok {
+		t.Error("expected the zap adapter at the bottom of the chain")
+	}
+
+	prometheusLogger, ok := cakelog.Find[*decorator.PrometheusLogger](logger)
+	if !ok {
+		t.Fatal("expected a prometheus decorator in the chain")
+	}
+
+	prometheusLogger.Info(context.Background(), "info message")
+	prometheusLogger.Info(context.Background(), "info message")
+
+	again, err := config.NewRegistry().Build(&config.Config{
+		Adapter: config.AdapterConfig{Type: "zap", Output: "discard"},
+		Decorators: []config.DecoratorConfig{
+			{Type: "prometheus", Options: config.Options{"name": "config_test_builtin_total", "namespace": "cakelog"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("expected the registered counter to be reused, got %v", err)
+	}
+
+	again.Info(context.Background(), "info message")
+
+	if value := counterValue(t, "cakelog_config_test_builtin_total", "info"); value != 3 {
+		t.Errorf("expected the info counter to be 3, got %f", value)
+	}
+}
+
+func TestBuiltinDecorators_InvalidOptions(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]config.DecoratorConfig{
+		"decorators[0].options.levels[1]": {Type: "sentry", Options: config.Options{"levels": []any{"warn", "loud"}}},
+		"decorators[0].options.dsn":       {Type: "sentry", Options: config.Options{"dsn": "not a dsn"}},
+		"decorators[0].options.help":      {Type: "prometheus", Options: config.Options{"help": 42}},
+		"decorators[0].options.key":       {Type: "context", Options: config.Options{"key": "value"}},
+	}
+
+	for path, dec := range tests {
+		_, err := config.NewRegistry().Build(&config.Config{
+			Adapter:    config.AdapterConfig{Type: "slog", Output: "discard"},
+			Decorators: []config.DecoratorConfig{dec},
+		})
+
+		var validationErr *config.ValidationError
+		if !errors.As(err, &validationErr) || validationErr.Path != path {
+			t.Errorf("expected a validation error at %s, got %v", path, err)
+		}
+	}
+}
@@ -0,0 +1,207 @@
+// Package config builds a cakelog.Logger stack from a declarative JSON or YAML document.
+//
+// A document selects the adapter that writes the messages, together with its level, output, format and ArgsKey,
+// and an ordered list of decorators wrapped around it, the first one being the outermost.
+// Adapters and decorators are created by factories looked up by name in a Registry,
+// where third-party packages can register their own.
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/yuppyweb/cakelog"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	// Is returned when a document cannot be decoded.
+	ErrInvalidDocument = errors.New("invalid config document")
+
+	// Is returned by LoadFile for files whose extension is not .json, .yaml or .yml.
+	ErrUnsupportedFormat = errors.New("unsupported config file format")
+
+	// Is reported when a required value of a document is missing.
+	ErrMissingValue = errors.New("missing value")
+
+	// Is reported when a value of a document has the wrong type or is not allowed.
+	ErrInvalidValue = errors.New("invalid value")
+
+	// Is reported for an option that the adapter or decorator does not know.
+	ErrUnknownOption = errors.New("unknown option")
+
+	// Is reported for an adapter name that is not registered.
+	ErrUnknownAdapter = errors.New("unknown adapter")
+
+	// Is reported for a decorator name that is not registered.
+	ErrUnknownDecorator = errors.New("unknown decorator")
+)
+
+// Is a logger stack described by a document.
+type Config struct {
+	// The adapter that writes the messages.
+	Adapter AdapterConfig `json:"adapter" yaml:"adapter"`
+
+	// The decorators wrapped around the adapter. The first decorator is the outermost one,
+	// as with cakelog.Chain.
+	Decorators []DecoratorConfig `json:"decorators" yaml:"decorators"`
+}
+
+// Is the adapter section of a document.
+type AdapterConfig struct {
+	// The registered name of the adapter, such as "slog", "zap", "zerolog" or "logrus".
+	Type string `json:"type" yaml:"type"`
+
+	// The lowest level written by the adapter, in the form accepted by cakelog.ParseLevel.
+	// An empty level is "info".
+	Level string `json:"level" yaml:"level"`
+
+	// Where the messages are written: "stdout", "stderr", "discard" or the path of a file,
+	// which is opened for appending and closed by cakelog.Close. An empty output is "stdout".
+	Output string `json:"output" yaml:"output"`
+
+	// The encoding of the messages, whose values depend on the adapter. An empty format is the default of the adapter.
+	Format string `json:"format" yaml:"format"`
+
+	// The key under which the adapter stores the arguments of a message. An empty key writes the arguments
+	// as top-level fields, and a missing or null key keeps the default of the adapter.
+	ArgsKey *string `json:"argsKey" yaml:"argsKey"`
+
+	// The options specific to the adapter.
+	Options Options `json:"options" yaml:"options"`
+}
+
+// Is a single decorator of a document.
+type DecoratorConfig struct {
+	// The registered name of the decorator, such as "context", "prometheus" or "sentry".
+	Type string `json:"type" yaml:"type"`
+
+	// The options specific to the decorator.
+	Options Options `json:"options" yaml:"options"`
+}
+
+// Is a problem found in a document, located by the path of the offending value,
+// such as "adapter.level" or "decorators[1].options.levels".
+type ValidationError struct {
+	// The path of the offending value in the document.
+	Path string
+
+	// The problem, which wraps one of the errors of this package.
+	Err error
+}
+
+// Returns the path and the problem.
+func (ve *ValidationError) Error() string {
+	return ve.Path + ": " + ve.Err.Error()
+}
+
+// Returns the problem.
+func (ve *ValidationError) Unwrap() error {
+	return ve.Err
+}
+
+// Decodes a JSON document. Unknown fields outside of the options are rejected.
+func ParseJSON(data []byte) (*Config, error) {
+	var cfg Config
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&cfg); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidDocument, err)
+	}
+
+	return &cfg, nil
+}
+
+// Decodes a YAML document. Unknown fields outside of the options are rejected.
+// An empty document decodes to an empty Config.
+func ParseYAML(data []byte) (*Config, error) {
+	var cfg Config
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	if err := decoder.Decode(&cfg); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidDocument, err)
+	}
+
+	return &cfg, nil
+}
+
+// Reads and decodes a document, choosing the format by the extension of the file.
+func LoadFile(path string) (*Config, error) {
+	var parse func([]byte) (*Config, error)
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		parse = ParseJSON
+	case ".yaml", ".yml":
+		parse = ParseYAML
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedFormat, path)
+	}
+
+	data, err := os.ReadFile(path) //nolint:gosec // The path of the config file is chosen by the application.
+	if err != nil {
+		return nil, fmt.Errorf("read config file: %w", err)
+	}
+
+	return parse(data)
+}
+
+// Checks the document against the default registry. See Registry.Validate.
+func Validate(cfg *Config) error {
+	return defaultRegistry.Validate(cfg)
+}
+
+// Builds the logger stack described by the document with the default registry. See Registry.Build.
+func Build(cfg *Config) (cakelog.Logger, error) {
+	return defaultRegistry.Build(cfg)
+}
+
+// Helper function to parse the level of the adapter section, which is info if empty.
+func parseLevel(text string) (cakelog.Level, error) {
+	if text == "" {
+		return cakelog.LevelInfo, nil
+	}
+
+	level, err := cakelog.ParseLevel(text)
+	if err != nil {
+		return 0, &ValidationError{Path: "adapter.level", Err: fmt.Errorf("%w: %w", ErrInvalidValue, err)}
+	}
+
+	return level, nil
+}
+
+// Helper function to prefix the paths of validation errors with the path of the section that reported them.
+// Other errors are reported at the path of the section.
+func atPath(prefix string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if joined, ok := err.(interface{ Unwrap() []error }); ok { //nolint:errorlint // Only joined errors are split.
+		errs := joined.Unwrap()
+		prefixed := make([]error, 0, len(errs))
+
+		for _, err := range errs {
+			prefixed = append(prefixed, atPath(prefix, err))
+		}
+
+		return errors.Join(prefixed...)
+	}
+
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		return &ValidationError{Path: prefix + "." + validationErr.Path, Err: validationErr.Err}
+	}
+
+	return &ValidationError{Path: prefix, Err: err}
+}
@@ -0,0 +1,162 @@
+package config_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/yuppyweb/cakelog"
+	"github.com/yuppyweb/cakelog/config"
+)
+
+const yamlDocument = `
+adapter:
+  type: slog
+  level: debug
+  output: %s
+  format: json
+  argsKey: args
+decorators:
+  - type: context
+  - type: prometheus
+    options:
+      name: config_test_yaml_total
+`
+
+func TestParseJSON(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := config.ParseJSON([]byte(`{
+		"adapter": {"type": "zap", "level": "warn", "options": {"addCaller": true}},
+		"decorators": [{"type": "sentry", "options": {"levels": ["error"]}}]
+	}`))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if cfg.Adapter.Type != "zap" || cfg.Adapter.Level != "warn" || cfg.Adapter.Options["addCaller"] != true {
+		t.Errorf("unexpected adapter section: %+v", cfg.Adapter)
+	}
+
+	if len(cfg.Decorators) != 1 || cfg.Decorators[0].Type != "sentry" {
+		t.Fatalf("unexpected decorators: %+v", cfg.Decorators)
+	}
+
+	if levels, err := cfg.Decorators[0].Options.Strings("levels", nil); err != nil || len(levels) != 1 {
+		t.Errorf("unexpected levels: %v %v", levels, err)
+	}
+}
+
+func TestParseJSON_UnknownField(t *testing.T) {
+	t.Parallel()
+
+	_, err := config.ParseJSON([]byte(`{"adapter": {"type": "zap", "lvl": "warn"}}`))
+	if !errors.Is(err, config.ErrInvalidDocument) {
+		t.Errorf("expected ErrInvalidDocument, got %v", err)
+	}
+}
+
+func TestParseYAML(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := config.ParseYAML([]byte(strings.Replace(yamlDocument, "%s", "discard", 1)))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	argsKey := cfg.Adapter.ArgsKey
+
+	if cfg.Adapter.Type != "slog" || argsKey == nil || *argsKey != "args" || cfg.Adapter.Output != "discard" {
+		t.Errorf("unexpected adapter section: %+v", cfg.Adapter)
+	}
+
+	if len(cfg.Decorators) != 2 || cfg.Decorators[1].Options["name"] != "config_test_yaml_total" {
+		t.Errorf("unexpected decorators: %+v", cfg.Decorators)
+	}
+
+	for document, expected := range map[string]*string{
+		"adapter:\n  type: zap\n":                  nil,
+		"adapter:\n  type: zap\n  argsKey:\n":      nil,
+		"adapter:\n  type: zap\n  argsKey: \"\"\n": new(""),
+	} {
+		cfg, err := config.ParseYAML([]byte(document))
+		if err != nil {
+			t.Fatalf("expected no error for %q, got %v", document, err)
+		}
+
+		if !reflect.DeepEqual(cfg.Adapter.ArgsKey, expected) {
+			t.Errorf("expected the argsKey of %q to be %v, got %v", document, expected, cfg.Adapter.ArgsKey)
+		}
+	}
+
+	if _, err := config.ParseYAML([]byte("adapter:\n  kind: slog\n")); !errors.Is(err, config.ErrInvalidDocument) {
+		t.Errorf("expected ErrInvalidDocument for an unknown field, got %v", err)
+	}
+
+	if cfg, err := config.ParseYAML(nil); err != nil || cfg.Adapter.Type != "" {
+		t.Errorf("expected an empty document to decode to an empty config, got %+v %v", cfg, err)
+	}
+}
+
+func TestLoadFile(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	logPath := filepath.Join(dir, "app.log")
+	configPath := filepath.Join(dir, "logging.yml")
+
+	if err := os.WriteFile(configPath, []byte(strings.Replace(yamlDocument, "%s", logPath, 1)), 0o600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	cfg, err := config.LoadFile(configPath)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	logger, err := config.Build(cfg)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	logger.Debug(context.Background(), "debug message", "user", 42)
+
+	if err := cakelog.Shutdown(context.Background(), logger); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	data, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatalf("failed to read log file: %v", err)
+	}
+
+	if !strings.Contains(string(data), `"msg":"debug message","args":{"user":42}`) {
+		t.Errorf("unexpected log file contents: %s", data)
+	}
+
+	if _, err := config.LoadFile(filepath.Join(dir, "logging.toml")); !errors.Is(err, config.ErrUnsupportedFormat) {
+		t.Errorf("expected ErrUnsupportedFormat, got %v", err)
+	}
+
+	if _, err := config.LoadFile(filepath.Join(dir, "missing.json")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected os.ErrNotExist, got %v", err)
+	}
+}
+
+func TestValidationError(t *testing.T) {
+	t.Parallel()
+
+	err := &config.ValidationError{Path: "adapter.type", Err: config.ErrMissingValue}
+
+	if err.Error() != "adapter.type: missing value" {
+		t.Errorf("unexpected message: %q", err.Error())
+	}
+
+	if !errors.Is(err, config.ErrMissingValue) {
+		t.Error("expected the error to wrap ErrMissingValue")
+	}
+}
@@ -0,0 +1,87 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// Is the options section of an adapter or a decorator, as decoded from a document.
+// Its methods read typed values and report problems as a ValidationError with the path "options.<key>",
+// which Build places under the path of the section.
+type Options map[string]any
+
+// Returns the string value of the option, or the fallback if it is not set.
+func (o Options) String(key, fallback string) (string, error) {
+	value, ok := o[key]
+	if !ok || value == nil {
+		return fallback, nil
+	}
+
+	text, ok := value.(string)
+	if !ok {
+		return "", optionError(key, fmt.Errorf("%w: expected a string, got %T", ErrInvalidValue, value))
+	}
+
+	return text, nil
+}
+
+// Returns the boolean value of the option, or the fallback if it is not set.
+func (o Options) Bool(key string, fallback bool) (bool, error) {
+	value, ok := o[key]
+	if !ok || value == nil {
+		return fallback, nil
+	}
+
+	flag, ok := value.(bool)
+	if !ok {
+		return false, optionError(key, fmt.Errorf("%w: expected a boolean, got %T", ErrInvalidValue, value))
+	}
+
+	return flag, nil
+}
+
+// Returns the list of strings of the option, or the fallback if it is not set.
+func (o Options) Strings(key string, fallback []string) ([]string, error) {
+	value, ok := o[key]
+	if !ok || value == nil {
+		return fallback, nil
+	}
+
+	items, ok := value.([]any)
+	if !ok {
+		return nil, optionError(key, fmt.Errorf("%w: expected a list of strings, got %T", ErrInvalidValue, value))
+	}
+
+	texts := make([]string, 0, len(items))
+
+	for idx, item := range items {
+		text, ok := item.(string)
+		if !ok {
+			return nil, optionError(
+				fmt.Sprintf("%s[%d]", key, idx),
+				fmt.Errorf("%w: expected a string, got %T", ErrInvalidValue, item),
+			)
+		}
+
+		texts = append(texts, text)
+	}
+
+	return texts, nil
+}
+
+// Reports the options that are not among the given keys, so misspelled options are not silently ignored.
+func (o Options) Only(keys ...string) error {
+	for _, key := range slices.Sorted(maps.Keys(o)) {
+		if !slices.Contains(keys, key) {
+			return optionError(key, ErrUnknownOption)
+		}
+	}
+
+	return nil
+}
+
+// Helper function to report a problem with an option.
+func optionError(key string, err error) error {
+	return &ValidationError{Path: "options." + key, Err: err}
+}
@@ -0,0 +1,100 @@
+package config_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/yuppyweb/cakelog/config"
+)
+
+func TestOptions(t *testing.T) {
+	t.Parallel()
+
+	options := config.Options{
+		"name":   "api",
+		"debug":  true,
+		"levels": []any{"warn", "error"},
+		"empty":  nil,
+	}
+
+	if value, err := options.String("name", "default"); err != nil || value != "api" {
+		t.Errorf("unexpected string: %q %v", value, err)
+	}
+
+	if value, err := options.String("empty", "default"); err != nil || value != "default" {
+		t.Errorf("expected the fallback for a null option, got %q %v", value, err)
+	}
+
+	if value, err := options.Bool("debug", false); err != nil || !value {
+		t.Errorf("unexpected boolean: %v %v", value, err)
+	}
+
+	if value, err := options.Bool("missing", true); err != nil || !value {
+		t.Errorf("expected the fallback for a missing option, got %v %v", value, err)
+	}
+
+	value, err := options.Strings("levels", nil)
+	if err != nil || !reflect.DeepEqual(value, []string{"warn", "error"}) {
+		t.Errorf("unexpected strings: %v %v", value, err)
+	}
+
+	if err := options.Only("name", "debug", "levels", "empty"); err != nil {
+		t.Errorf("expected no unknown options, got %v", err)
+	}
+
+	var nilOptions config.Options
+
+	if value, err := nilOptions.String("name", "default"); err != nil || value != "default" {
+		t.Errorf("expected nil options to return the fallback, got %q %v", value, err)
+	}
+}
+
+func TestOptions_Errors(t *testing.T) {
+	t.Parallel()
+
+	options := config.Options{
+		"name":   42,
+		"debug":  "yes",
+		"levels": []any{"warn", 8},
+		"tags":   "a,b",
+	}
+
+	tests := map[string]error{
+		"options.name": func() error {
+			_, err := options.String("name", "")
+
+			return err
+		}(),
+		"options.debug": func() error {
+			_, err := options.Bool("debug", false)
+
+			return err
+		}(),
+		"options.levels[1]": func() error {
+			_, err := options.Strings("levels", nil)
+
+			return err
+		}(),
+		"options.tags": func() error {
+			_, err := options.Strings("tags", nil)
+
+			return err
+		}(),
+	}
+
+	for path, err := range tests {
+		var validationErr *config.ValidationError
+		if !errors.As(err, &validationErr) || validationErr.Path != path || !errors.Is(err, config.ErrInvalidValue) {
+			t.Errorf("expected an invalid value at %s, got %v", path, err)
+		}
+	}
+
+	err := options.Only("name", "levels")
+
+	var validationErr *config.ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Path != "options.debug" ||
+		!errors.Is(err, config.ErrUnknownOption) {
+		t.Errorf("expected the first unknown option to be reported, got %v", err)
+	}
+}
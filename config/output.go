@@ -0,0 +1,106 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/yuppyweb/cakelog"
+)
+
+// Is the permission of log files created by Build.
+const outputFileMode = 0o600
+
+// Is a logger that closes the file written by its adapter when it is closed.
+type outputLogger struct {
+	// Provides the logging methods, which pass every message to Handle as a cakelog.Record.
+	*cakelog.HandlerLogger
+
+	// The adapter writing to the file.
+	log cakelog.Logger
+
+	// The file written by the adapter.
+	file io.Closer
+}
+
+// Writes the record to the adapter.
+func (ol *outputLogger) Handle(record cakelog.Record) {
+	cakelog.Handle(ol.log, record)
+}
+
+// Reports whether the adapter would write a message at the given level.
+func (ol *outputLogger) Enabled(ctx context.Context, level cakelog.Level) bool {
+	return cakelog.Enabled(ctx, ol.log, level)
+}
+
+// Flushes the buffered messages of the adapter.
+func (ol *outputLogger) Sync(ctx context.Context) error {
+	return cakelog.Sync(ctx, ol.log)
+}
+
+// Releases the resources of the adapter and closes the file.
+func (ol *outputLogger) Close(ctx context.Context) error {
+	return errors.Join(cakelog.Close(ctx, ol.log), ol.file.Close())
+}
+
+// Returns the adapter.
+func (ol *outputLogger) Unwrap() cakelog.Logger {
+	return ol.log
+}
+
+// Returns a child logger of the adapter, which does not close the file.
+func (ol *outputLogger) With(args ...any) cakelog.Logger {
+	return cakelog.With(ol.log, args...)
+}
+
+// Returns a child logger of the adapter with a group, which does not close the file.
+func (ol *outputLogger) WithGroup(name string) cakelog.Logger {
+	return cakelog.WithGroup(ol.log, name)
+}
+
+// Helper function to create an outputLogger whose logging methods pass records to its Handle method.
+func newOutputLogger(log cakelog.Logger, file io.Closer) *outputLogger {
+	ol := &outputLogger{
+		log:  log,
+		file: file,
+	}
+	ol.HandlerLogger = cakelog.NewHandlerLogger(ol)
+
+	return ol
+}
+
+// Helper function to open the output of the adapter section.
+// The returned closer is nil unless a file was opened.
+func openOutput(output string) (io.Writer, io.Closer, error) {
+	switch output {
+	case "", "stdout":
+		return os.Stdout, nil, nil
+	case "stderr":
+		return os.Stderr, nil, nil
+	case "discard":
+		return io.Discard, nil, nil
+	}
+
+	file, err := os.OpenFile(output, os.O_WRONLY|os.O_APPEND|os.O_CREATE, outputFileMode)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", ErrInvalidValue, err)
+	}
+
+	return file, file, nil
+}
+
+var (
+	// Ensures that outputLogger implements the cakelog.Handler interface.
+	_ cakelog.Handler = (*outputLogger)(nil)
+
+	// Ensures that outputLogger implements the cakelog.WithLogger interface.
+	_ cakelog.WithLogger = (*outputLogger)(nil)
+
+	// Ensures that outputLogger implements the cakelog.GroupLogger interface.
+	_ cakelog.GroupLogger = (*outputLogger)(nil)
+
+	// Ensures that outputLogger implements the cakelog.Closer interface.
+	_ cakelog.Closer = (*outputLogger)(nil)
+)
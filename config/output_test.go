@@ -0,0 +1,69 @@
+package config_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/yuppyweb/cakelog"
+	"github.com/yuppyweb/cakelog/config"
+)
+
+func TestBuild_FileOutput(t *testing.T) {
+	t.Parallel()
+
+	logPath := filepath.Join(t.TempDir(), "app.log")
+
+	logger, err := config.NewRegistry().Build(&config.Config{
+		Adapter: config.AdapterConfig{Type: "logrus", Output: logPath, Format: "text"},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	child := cakelog.WithGroup(cakelog.With(logger, "service", "api"), "http")
+
+	logger.Info(context.Background(), "first message")
+	child.Warn(context.Background(), "second message")
+
+	if cakelog.Enabled(context.Background(), logger, cakelog.LevelDebug) {
+		t.Error("expected debug to be disabled by the default level")
+	}
+
+	if err := cakelog.Shutdown(context.Background(), logger); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	data, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatalf("failed to read log file: %v", err)
+	}
+
+	if lines := strings.Count(string(data), "\n"); lines != 2 || !strings.Contains(string(data), "service=api") {
+		t.Errorf("unexpected log file contents: %s", data)
+	}
+
+	if err := cakelog.Close(context.Background(), logger); !errors.Is(err, os.ErrClosed) {
+		t.Errorf("expected closing twice to report os.ErrClosed, got %v", err)
+	}
+}
+
+func TestBuild_InvalidOutput(t *testing.T) {
+	t.Parallel()
+
+	_, err := config.NewRegistry().Build(&config.Config{
+		Adapter: config.AdapterConfig{Type: "slog", Output: filepath.Join(t.TempDir(), "missing", "app.log")},
+	})
+
+	var validationErr *config.ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Path != "adapter.output" {
+		t.Fatalf("expected a validation error at adapter.output, got %v", err)
+	}
+
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected the error to wrap os.ErrNotExist, got %v", err)
+	}
+}
@@ -0,0 +1,250 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/yuppyweb/cakelog"
+)
+
+// Creates the logger that writes the messages of a stack from the adapter section of a document.
+type AdapterFactory func(spec AdapterSpec) (cakelog.Logger, error)
+
+// Wraps the logger built so far with a decorator configured by the options of a document.
+type DecoratorFactory func(logger cakelog.Logger, options Options) (cakelog.Logger, error)
+
+// Is the adapter section of a document, resolved for an AdapterFactory.
+// Errors about the format should be reported as a ValidationError with the path "format".
+type AdapterSpec struct {
+	// The lowest level to be written.
+	Level cakelog.Level
+
+	// The destination of the messages.
+	Output io.Writer
+
+	// The encoding of the messages, or empty for the default of the adapter.
+	Format string
+
+	// The key under which the arguments are stored, empty for top-level fields, or nil for the default of the adapter.
+	ArgsKey *string
+
+	// The options specific to the adapter.
+	Options Options
+}
+
+// Is a set of adapter and decorator factories looked up by name when a document is built.
+// It is safe for concurrent use.
+type Registry struct {
+	// Guards the factories.
+	mu sync.RWMutex
+
+	// The adapter factories by name.
+	adapters map[string]AdapterFactory
+
+	// The decorator factories by name.
+	decorators map[string]DecoratorFactory
+}
+
+// Holds the registry used by the package-level functions, with the built-in factories registered.
+//
+//nolint:gochecknoglobals // The default registry is process-wide by design, like the default logger.
+var defaultRegistry = NewRegistry()
+
+// Creates a new Registry with the built-in adapters ("slog", "zap", "zerolog" and "logrus")
+// and decorators ("context", "prometheus" and "sentry") registered.
+func NewRegistry() *Registry {
+	registry := &Registry{
+		adapters:   make(map[string]AdapterFactory),
+		decorators: make(map[string]DecoratorFactory),
+	}
+
+	registry.RegisterAdapter("slog", newSlogAdapter)
+	registry.RegisterAdapter("zap", newZapAdapter)
+	registry.RegisterAdapter("zerolog", newZerologAdapter)
+	registry.RegisterAdapter("logrus", newLogrusAdapter)
+	registry.RegisterDecorator("context", newContextDecorator)
+	registry.RegisterDecorator("prometheus", newPrometheusDecorator)
+	registry.RegisterDecorator("sentry", newSentryDecorator)
+
+	return registry
+}
+
+// Registers an adapter factory in the default registry. See Registry.RegisterAdapter.
+func RegisterAdapter(name string, factory AdapterFactory) {
+	defaultRegistry.RegisterAdapter(name, factory)
+}
+
+// Registers a decorator factory in the default registry. See Registry.RegisterDecorator.
+func RegisterDecorator(name string, factory DecoratorFactory) {
+	defaultRegistry.RegisterDecorator(name, factory)
+}
+
+// Registers an adapter factory under the given name, replacing the factory registered under it before.
+// A nil factory removes the name.
+func (r *Registry) RegisterAdapter(name string, factory AdapterFactory) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if factory == nil {
+		delete(r.adapters, name)
+
+		return
+	}
+
+	r.adapters[name] = factory
+}
+
+// Registers a decorator factory under the given name, replacing the factory registered under it before.
+// A nil factory removes the name.
+func (r *Registry) RegisterDecorator(name string, factory DecoratorFactory) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if factory == nil {
+		delete(r.decorators, name)
+
+		return
+	}
+
+	r.decorators[name] = factory
+}
+
+// Returns the sorted names of the registered adapters.
+func (r *Registry) Adapters() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.adapterNames()
+}
+
+// Returns the sorted names of the registered decorators.
+func (r *Registry) Decorators() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.decoratorNames()
+}
+
+// Checks that the document names registered adapters and decorators and has a valid level.
+// All problems are reported at once as ValidationError values joined with errors.Join.
+// The options are checked by the factories, so their problems are only reported by Build.
+// A nil document is reported as a missing adapter section.
+func (r *Registry) Validate(cfg *Config) error {
+	if cfg == nil {
+		return &ValidationError{Path: "adapter", Err: ErrMissingValue}
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var errs []error
+
+	switch _, ok := r.adapters[cfg.Adapter.Type]; {
+	case cfg.Adapter.Type == "":
+		errs = append(errs, &ValidationError{Path: "adapter.type", Err: ErrMissingValue})
+	case !ok:
+		errs = append(errs, &ValidationError{
+			Path: "adapter.type",
+			Err: fmt.Errorf(
+				"%w %q, registered adapters are %q", ErrUnknownAdapter, cfg.Adapter.Type, r.adapterNames(),
+			),
+		})
+	}
+
+	if _, err := parseLevel(cfg.Adapter.Level); err != nil {
+		errs = append(errs, err)
+	}
+
+	for idx, dec := range cfg.Decorators {
+		path := fmt.Sprintf("decorators[%d].type", idx)
+
+		switch _, ok := r.decorators[dec.Type]; {
+		case dec.Type == "":
+			errs = append(errs, &ValidationError{Path: path, Err: ErrMissingValue})
+		case !ok:
+			errs = append(errs, &ValidationError{
+				Path: path,
+				Err: fmt.Errorf(
+					"%w %q, registered decorators are %q", ErrUnknownDecorator, dec.Type, r.decoratorNames(),
+				),
+			})
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Builds the logger stack described by the document: the adapter first, then the decorators from the last to the first.
+// The document is validated first, and problems reported by the factories are returned as ValidationError values
+// located in the document. If building fails, everything built so far is closed.
+func (r *Registry) Build(cfg *Config) (cakelog.Logger, error) {
+	if err := r.Validate(cfg); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	adapterFactory := r.adapters[cfg.Adapter.Type]
+	decoratorFactories := make([]DecoratorFactory, 0, len(cfg.Decorators))
+
+	for _, dec := range cfg.Decorators {
+		decoratorFactories = append(decoratorFactories, r.decorators[dec.Type])
+	}
+	r.mu.RUnlock()
+
+	level, err := parseLevel(cfg.Adapter.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	output, closer, err := openOutput(cfg.Adapter.Output)
+	if err != nil {
+		return nil, atPath("adapter.output", err)
+	}
+
+	logger, err := adapterFactory(AdapterSpec{
+		Level:   level,
+		Output:  output,
+		Format:  cfg.Adapter.Format,
+		ArgsKey: cfg.Adapter.ArgsKey,
+		Options: cfg.Adapter.Options,
+	})
+	if err != nil {
+		if closer != nil {
+			_ = closer.Close()
+		}
+
+		return nil, atPath("adapter", err)
+	}
+
+	if closer != nil {
+		logger = newOutputLogger(logger, closer)
+	}
+
+	for idx := len(decoratorFactories) - 1; idx >= 0; idx-- {
+		decorated, err := decoratorFactories[idx](logger, cfg.Decorators[idx].Options)
+		if err != nil {
+			_ = cakelog.Close(context.Background(), logger)
+
+			return nil, atPath(fmt.Sprintf("decorators[%d]", idx), err)
+		}
+
+		logger = decorated
+	}
+
+	return logger, nil
+}
+
+// Helper method to list the sorted adapter names while the lock is held.
+func (r *Registry) adapterNames() []string {
+	return slices.Sorted(maps.Keys(r.adapters))
+}
+
+// Helper method to list the sorted decorator names while the lock is held.
+func (r *Registry) decoratorNames() []string {
+	return slices.Sorted(maps.Keys(r.decorators))
+}
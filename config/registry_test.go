@@ -0,0 +1,242 @@
+package config_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/yuppyweb/cakelog"
+	"github.com/yuppyweb/cakelog/config"
+)
+
+type mockLogger struct {
+	cakelog.NopLogger
+
+	spec   config.AdapterSpec
+	closed bool
+}
+
+func (ml *mockLogger) Close(context.Context) error {
+	ml.closed = true
+
+	return nil
+}
+
+type mockDecorator struct {
+	cakelog.NopLogger
+
+	name string
+	next cakelog.Logger
+}
+
+func (md *mockDecorator) Unwrap() cakelog.Logger {
+	return md.next
+}
+
+var (
+	_ cakelog.Closer    = (*mockLogger)(nil)
+	_ cakelog.Unwrapper = (*mockDecorator)(nil)
+)
+
+func newMockRegistry(log *mockLogger) *config.Registry {
+	registry := config.NewRegistry()
+
+	registry.RegisterAdapter("mock", func(spec config.AdapterSpec) (cakelog.Logger, error) {
+		log.spec = spec
+
+		return log, nil
+	})
+
+	registry.RegisterDecorator("named", func(logger cakelog.Logger, options config.Options) (cakelog.Logger, error) {
+		if err := options.Only("name"); err != nil {
+			return nil, err
+		}
+
+		name, err := options.String("name", "")
+		if err != nil {
+			return nil, err
+		}
+
+		return &mockDecorator{name: name, next: logger}, nil
+	})
+
+	return registry
+}
+
+func TestRegistry_Build(t *testing.T) {
+	t.Parallel()
+
+	log := new(mockLogger)
+	registry := newMockRegistry(log)
+
+	logger, err := registry.Build(&config.Config{
+		Adapter: config.AdapterConfig{
+			Type:    "mock",
+			Level:   "warn+1",
+			Output:  "discard",
+			Format:  "raw",
+			ArgsKey: new("args"),
+		},
+		Decorators: []config.DecoratorConfig{
+			{Type: "named", Options: config.Options{"name": "outer"}},
+			{Type: "named", Options: config.Options{"name": "inner"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	var names []string
+
+	cakelog.Walk(logger, func(logger cakelog.Logger) bool {
+		if dec, ok := logger.(*mockDecorator); ok {
+			names = append(names, dec.name)
+		}
+
+		return true
+	})
+
+	if !reflect.DeepEqual(names, []string{"outer", "inner"}) {
+		t.Errorf("expected the first decorator to be the outermost, got %v", names)
+	}
+
+	if log.spec.Level != cakelog.LevelWarn+1 || log.spec.Format != "raw" ||
+		!reflect.DeepEqual(log.spec.ArgsKey, new("args")) {
+		t.Errorf("unexpected adapter spec: %+v", log.spec)
+	}
+}
+
+func TestRegistry_Validate(t *testing.T) {
+	t.Parallel()
+
+	registry := newMockRegistry(new(mockLogger))
+
+	err := registry.Validate(&config.Config{
+		Adapter: config.AdapterConfig{Type: "mongo", Level: "verbose"},
+		Decorators: []config.DecoratorConfig{
+			{Type: "named"},
+			{Type: ""},
+			{Type: "audit"},
+		},
+	})
+
+	for _, expected := range []string{
+		`adapter.type: unknown adapter "mongo"`,
+		`adapter.level: invalid value: unknown log level: "verbose"`,
+		"decorators[1].type: missing value",
+		`decorators[2].type: unknown decorator "audit"`,
+	} {
+		if err == nil || !strings.Contains(err.Error(), expected) {
+			t.Errorf("expected the error to contain %q, got %v", expected, err)
+		}
+	}
+
+	for _, target := range []error{config.ErrUnknownAdapter, config.ErrUnknownDecorator, config.ErrMissingValue} {
+		if !errors.Is(err, target) {
+			t.Errorf("expected the error to wrap %v", target)
+		}
+	}
+
+	if err := registry.Validate(&config.Config{}); !errors.Is(err, config.ErrMissingValue) {
+		t.Errorf("expected a missing adapter type to be reported, got %v", err)
+	}
+
+	var validationErr *config.ValidationError
+
+	if err := registry.Validate(nil); !errors.As(err, &validationErr) || validationErr.Path != "adapter" {
+		t.Errorf("expected a nil document to be reported as a validation error, got %v", err)
+	}
+
+	if logger, err := registry.Build(nil); logger != nil || !errors.Is(err, config.ErrMissingValue) {
+		t.Errorf("expected a nil document not to be built, got %v %v", logger, err)
+	}
+}
+
+func TestRegistry_BuildFailure(t *testing.T) {
+	t.Parallel()
+
+	log := new(mockLogger)
+	registry := newMockRegistry(log)
+
+	_, err := registry.Build(&config.Config{
+		Adapter: config.AdapterConfig{Type: "mock", Output: "discard"},
+		Decorators: []config.DecoratorConfig{
+			{Type: "named", Options: config.Options{"name": 42}},
+			{Type: "named", Options: config.Options{"name": "inner", "nmae": "typo"}},
+		},
+	})
+
+	var validationErr *config.ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Path != "decorators[1].options.nmae" {
+		t.Fatalf("expected a validation error at decorators[1].options.nmae, got %v", err)
+	}
+
+	if !errors.Is(err, config.ErrUnknownOption) {
+		t.Errorf("expected ErrUnknownOption, got %v", err)
+	}
+
+	if !log.closed {
+		t.Error("expected the adapter to be closed after a failed build")
+	}
+
+	_, err = registry.Build(&config.Config{
+		Adapter:    config.AdapterConfig{Type: "mock", Output: "discard"},
+		Decorators: []config.DecoratorConfig{{Type: "named", Options: config.Options{"name": 42}}},
+	})
+
+	if err == nil || err.Error() != "decorators[0].options.name: invalid value: expected a string, got int" {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestRegistry_Names(t *testing.T) {
+	t.Parallel()
+
+	registry := config.NewRegistry()
+
+	if names := registry.Adapters(); !reflect.DeepEqual(names, []string{"logrus", "slog", "zap", "zerolog"}) {
+		t.Errorf("unexpected adapters: %v", names)
+	}
+
+	registry.RegisterDecorator("sentry", nil)
+
+	if names := registry.Decorators(); !reflect.DeepEqual(names, []string{"context", "prometheus"}) {
+		t.Errorf("unexpected decorators: %v", names)
+	}
+}
+
+func TestRegisterAdapter(t *testing.T) {
+	t.Parallel()
+
+	log := new(mockLogger)
+
+	config.RegisterAdapter("config-test-adapter", func(config.AdapterSpec) (cakelog.Logger, error) {
+		return log, nil
+	})
+	config.RegisterDecorator(
+		"config-test-decorator",
+		func(logger cakelog.Logger, _ config.Options) (cakelog.Logger, error) {
+			return &mockDecorator{name: "registered", next: logger}, nil
+		},
+	)
+
+	cfg := &config.Config{
+		Adapter:    config.AdapterConfig{Type: "config-test-adapter", Output: "discard"},
+		Decorators: []config.DecoratorConfig{{Type: "config-test-decorator"}},
+	}
+
+	if err := config.Validate(cfg); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	logger, err := config.Build(cfg)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if found, ok := cakelog.Find[*mockLogger](logger); !ok || found != log {
+		t.Errorf("expected the registered adapter to be built, got %v", found)
+	}
+}
@@ -21,6 +21,9 @@ type contextLoggerKey struct{}
 
 // Is a decorator that enriches log messages with context values stored using the PutContext method.
 type ContextLogger struct {
+	// Provides the logging methods, which pass every message to Handle as a cakelog.Record.
+	*cakelog.HandlerLogger
+
 	// The underlying cakelog.Logger to which log messages will be forwarded.
 	log cakelog.Logger
 
@@ -31,34 +34,57 @@ type ContextLogger struct {
 
 // Creates a new ContextLogger that wraps the provided cakelog.Logger.
 func NewContextLogger(log cakelog.Logger) *ContextLogger {
-	return &ContextLogger{
-		log: log,
-		key: &contextLoggerKey{},
+	return newContextLogger(log, &contextLoggerKey{})
+}
+
+// Returns a cakelog.Middleware that wraps a logger in a new ContextLogger.
+// Every application of the middleware creates a ContextLogger with its own context key.
+func ContextMiddleware() cakelog.Middleware {
+	return func(log cakelog.Logger) cakelog.Logger {
+		return NewContextLogger(log)
 	}
 }
 
-// Sends a debug message to the underlying cakelog.Logger with the provided context and arguments,
-// enriched with context values.
-func (cl *ContextLogger) Debug(ctx context.Context, msg string, args ...any) {
-	cl.log.Debug(ctx, msg, cl.enrichArgsContext(ctx, args)...)
+// Adds the context values stored in the context of the record to its attributes, sorted by key,
+// and forwards the record to the underlying cakelog.Logger.
+func (cl *ContextLogger) Handle(record cakelog.Record) {
+	if record.Context != nil {
+		record.Add(cl.contextArgs(record.Context)...)
+	}
+
+	cakelog.Handle(cl.log, record)
+}
+
+// Reports whether the underlying cakelog.Logger would write a message at the given level.
+func (cl *ContextLogger) Enabled(ctx context.Context, level cakelog.Level) bool {
+	return cakelog.Enabled(ctx, cl.log, level)
 }
 
-// Sends an info message to the underlying cakelog.Logger with the provided context and arguments,
-// enriched with context values.
-func (cl *ContextLogger) Info(ctx context.Context, msg string, args ...any) {
-	cl.log.Info(ctx, msg, cl.enrichArgsContext(ctx, args)...)
+// Flushes the buffered messages of the underlying cakelog.Logger.
+func (cl *ContextLogger) Sync(ctx context.Context) error {
+	return cakelog.Sync(ctx, cl.log)
 }
 
-// Sends a warning message to the underlying cakelog.Logger with the provided context and arguments,
-// enriched with context values.
-func (cl *ContextLogger) Warn(ctx context.Context, msg string, args ...any) {
-	cl.log.Warn(ctx, msg, cl.enrichArgsContext(ctx, args)...)
+// Releases the resources of the underlying cakelog.Logger.
+func (cl *ContextLogger) Close(ctx context.Context) error {
+	return cakelog.Close(ctx, cl.log)
 }
 
-// Sends an error message to the underlying cakelog.Logger with the provided context and arguments,
-// enriched with context values.
-func (cl *ContextLogger) Error(ctx context.Context, err error, args ...any) {
-	cl.log.Error(ctx, err, cl.enrichArgsContext(ctx, args)...)
+// Returns the underlying cakelog.Logger.
+func (cl *ContextLogger) Unwrap() cakelog.Logger {
+	return cl.log
+}
+
+// Returns a ContextLogger that binds the given arguments to the underlying logger with cakelog.With.
+// The returned logger shares the context values with this one.
+func (cl *ContextLogger) With(args ...any) cakelog.Logger {
+	return newContextLogger(cakelog.With(cl.log, args...), cl.key)
+}
+
+// Returns a ContextLogger that nests the arguments in a group of the underlying logger with cakelog.WithGroup.
+// The returned logger shares the context values with this one.
+func (cl *ContextLogger) WithGroup(name string) cakelog.Logger {
+	return newContextLogger(cakelog.WithGroup(cl.log, name), cl.key)
 }
 
 // Puts a key-value pair into the context that will be included in all log messages sent through this ContextLogger.
@@ -78,29 +104,69 @@ func (cl *ContextLogger) PutContext(ctx context.Context, key string, value any)
 	return context.WithValue(ctx, cl.key, newSm)
 }
 
-// Helper method to enrich log arguments with context values stored in the context.
-func (cl *ContextLogger) enrichArgsContext(ctx context.Context, args []any) []any {
-	if sm, ok := ctx.Value(cl.key).(*sync.Map); ok {
-		ctxArgs := make(map[any]any)
+// Helper function to create a ContextLogger whose logging methods pass records to its Handle method.
+func newContextLogger(log cakelog.Logger, key *contextLoggerKey) *ContextLogger {
+	cl := &ContextLogger{
+		log: log,
+		key: key,
+	}
+	cl.HandlerLogger = cakelog.NewHandlerLogger(cl)
 
-		sm.Range(func(k, v any) bool {
-			ctxArgs[k] = v
+	return cl
+}
 
-			return true
-		})
+// Helper method to collect the context values stored in the context as arguments sorted by key.
+func (cl *ContextLogger) contextArgs(ctx context.Context) []any {
+	sm, ok := ctx.Value(cl.key).(*sync.Map)
+	if !ok {
+		return nil
+	}
 
-		if len(ctxArgs) > 0 {
-			args = append(args, ctxArgs)
+	ctxArgs := make(map[string]any)
+
+	sm.Range(func(k, v any) bool {
+		if key, ok := k.(string); ok {
+			ctxArgs[key] = v
 		}
+
+		return true
+	})
+
+	if len(ctxArgs) == 0 {
+		return nil
 	}
 
-	return args
+	return []any{ctxArgs}
 }
 
 var (
 	// Ensures that ContextLogger implements both the cakelog.Logger and ContextEnricher interfaces.
 	_ cakelog.Logger = (*ContextLogger)(nil)
 
+	// Ensures that ContextLogger implements the cakelog.Handler interface.
+	_ cakelog.Handler = (*ContextLogger)(nil)
+
+	// Ensures that ContextLogger implements the cakelog.LevelLogger interface.
+	_ cakelog.LevelLogger = (*ContextLogger)(nil)
+
+	// Ensures that ContextLogger implements the cakelog.WithLogger interface.
+	_ cakelog.WithLogger = (*ContextLogger)(nil)
+
+	// Ensures that ContextLogger implements the cakelog.GroupLogger interface.
+	_ cakelog.GroupLogger = (*ContextLogger)(nil)
+
+	// Ensures that ContextLogger implements the cakelog.Enabler interface.
+	_ cakelog.Enabler = (*ContextLogger)(nil)
+
+	// Ensures that ContextLogger implements the cakelog.Syncer interface.
+	_ cakelog.Syncer = (*ContextLogger)(nil)
+
+	// Ensures that ContextLogger implements the cakelog.Closer interface.
+	_ cakelog.Closer = (*ContextLogger)(nil)
+
+	// Ensures that ContextLogger implements the cakelog.Unwrapper interface.
+	_ cakelog.Unwrapper = (*ContextLogger)(nil)
+
 	// Ensures that ContextLogger implements the ContextEnricher interface.
 	_ ContextEnricher = (*ContextLogger)(nil)
 )
@@ -3,8 +3,11 @@ package decorator_test
 import (
 	"context"
 	"errors"
+	"fmt"
+	"reflect"
 	"testing"
 
+	"github.com/yuppyweb/cakelog"
 	"github.com/yuppyweb/cakelog/decorator"
 )
 
@@ -30,9 +33,9 @@ func TestContextLogger_Debug(t *testing.T) {
 		)
 	}
 
-	if len(mockLogger.debugIn[0].args) != 3 {
+	if len(mockLogger.debugIn[0].args) != 4 {
 		t.Fatalf(
-			"Expected Debug to be called with 3 arguments, got %d",
+			"Expected Debug to be called with 4 arguments, got %d",
 			len(mockLogger.debugIn[0].args),
 		)
 	}
@@ -51,23 +54,17 @@ func TestContextLogger_Debug(t *testing.T) {
 		)
 	}
 
-	ctxValue, ok := mockLogger.debugIn[0].args[2].(map[any]any)
-	if !ok {
-		t.Fatalf(
-			"Expected third argument to be a map[string]any, got '%T'",
+	if mockLogger.debugIn[0].args[2] != "userID" {
+		t.Errorf(
+			"Expected third argument to be 'userID', got '%v'",
 			mockLogger.debugIn[0].args[2],
 		)
 	}
 
-	userID, ok := ctxValue["userID"]
-	if !ok {
-		t.Fatalf("Expected context to contain key 'userID'")
-	}
-
-	if userID != 123 {
+	if mockLogger.debugIn[0].args[3] != 123 {
 		t.Errorf(
 			"Expected context value for 'userID' to be 123, got '%v'",
-			userID,
+			mockLogger.debugIn[0].args[3],
 		)
 	}
 }
@@ -94,9 +91,9 @@ func TestContextLogger_Info(t *testing.T) {
 		)
 	}
 
-	if len(mockLogger.infoIn[0].args) != 3 {
+	if len(mockLogger.infoIn[0].args) != 4 {
 		t.Fatalf(
-			"Expected Info to be called with 3 arguments, got %d",
+			"Expected Info to be called with 4 arguments, got %d",
 			len(mockLogger.infoIn[0].args),
 		)
 	}
@@ -115,23 +112,17 @@ func TestContextLogger_Info(t *testing.T) {
 		)
 	}
 
-	ctxValue, ok := mockLogger.infoIn[0].args[2].(map[any]any)
-	if !ok {
-		t.Fatalf(
-			"Expected third argument to be a map[string]any, got '%T'",
+	if mockLogger.infoIn[0].args[2] != "requestID" {
+		t.Errorf(
+			"Expected third argument to be 'requestID', got '%v'",
 			mockLogger.infoIn[0].args[2],
 		)
 	}
 
-	requestID, ok := ctxValue["requestID"]
-	if !ok {
-		t.Fatalf("Expected context to contain key 'requestID'")
-	}
-
-	if requestID != "abc-123" {
+	if mockLogger.infoIn[0].args[3] != "abc-123" {
 		t.Errorf(
 			"Expected context value for 'requestID' to be 'abc-123', got '%v'",
-			requestID,
+			mockLogger.infoIn[0].args[3],
 		)
 	}
 }
@@ -158,9 +149,9 @@ func TestContextLogger_Warn(t *testing.T) {
 		)
 	}
 
-	if len(mockLogger.warnIn[0].args) != 3 {
+	if len(mockLogger.warnIn[0].args) != 4 {
 		t.Fatalf(
-			"Expected Warn to be called with 3 arguments, got %d",
+			"Expected Warn to be called with 4 arguments, got %d",
 			len(mockLogger.warnIn[0].args),
 		)
 	}
@@ -179,23 +170,17 @@ func TestContextLogger_Warn(t *testing.T) {
 		)
 	}
 
-	ctxValue, ok := mockLogger.warnIn[0].args[2].(map[any]any)
-	if !ok {
-		t.Fatalf(
-			"Expected third argument to be a map[string]any, got '%T'",
+	if mockLogger.warnIn[0].args[2] != "sessionID" {
+		t.Errorf(
+			"Expected third argument to be 'sessionID', got '%v'",
 			mockLogger.warnIn[0].args[2],
 		)
 	}
 
-	sessionID, ok := ctxValue["sessionID"]
-	if !ok {
-		t.Fatalf("Expected context to contain key 'sessionID'")
-	}
-
-	if sessionID != "xyz-789" {
+	if mockLogger.warnIn[0].args[3] != "xyz-789" {
 		t.Errorf(
 			"Expected context value for 'sessionID' to be 'xyz-789', got '%v'",
-			sessionID,
+			mockLogger.warnIn[0].args[3],
 		)
 	}
 }
@@ -224,9 +209,9 @@ func TestContextLogger_Error(t *testing.T) {
 		)
 	}
 
-	if len(mockLogger.errorIn[0].args) != 3 {
+	if len(mockLogger.errorIn[0].args) != 4 {
 		t.Fatalf(
-			"Expected Error to be called with 3 arguments, got %d",
+			"Expected Error to be called with 4 arguments, got %d",
 			len(mockLogger.errorIn[0].args),
 		)
 	}
@@ -245,23 +230,17 @@ func TestContextLogger_Error(t *testing.T) {
 		)
 	}
 
-	ctxValue, ok := mockLogger.errorIn[0].args[2].(map[any]any)
-	if !ok {
-		t.Fatalf(
-			"Expected third argument to be a map[string]any, got '%T'",
+	if mockLogger.errorIn[0].args[2] != "transactionID" {
+		t.Errorf(
+			"Expected third argument to be 'transactionID', got '%v'",
 			mockLogger.errorIn[0].args[2],
 		)
 	}
 
-	transactionID, ok := ctxValue["transactionID"]
-	if !ok {
-		t.Fatalf("Expected context to contain key 'transactionID'")
-	}
-
-	if transactionID != "txn-456" {
+	if mockLogger.errorIn[0].args[3] != "txn-456" {
 		t.Errorf(
 			"Expected context value for 'transactionID' to be 'txn-456', got '%v'",
-			transactionID,
+			mockLogger.errorIn[0].args[3],
 		)
 	}
 }
@@ -387,100 +366,188 @@ func TestContextLogger_ImmutableContext(t *testing.T) {
 		t.Fatalf("Expected Error to be called once, got %d calls", len(mockLogger.errorIn))
 	}
 
-	if len(mockLogger.debugIn[0].args) != 1 {
-		t.Fatalf(
-			"Expected Debug to be called with 1 argument, got %d",
-			len(mockLogger.debugIn[0].args),
-		)
+	calls := [][]any{
+		mockLogger.debugIn[0].args,
+		mockLogger.infoIn[0].args,
+		mockLogger.warnIn[0].args,
+		mockLogger.errorIn[0].args,
 	}
 
-	if len(mockLogger.infoIn[0].args) != 1 {
-		t.Fatalf(
-			"Expected Info to be called with 1 argument, got %d",
-			len(mockLogger.infoIn[0].args),
-		)
+	for idx, args := range calls {
+		expected := make([]any, 0, 2*(idx+1))
+
+		for key := 1; key <= idx+1; key++ {
+			expected = append(expected, fmt.Sprintf("key%d", key), fmt.Sprintf("value%d", key))
+		}
+
+		if !reflect.DeepEqual(args, expected) {
+			t.Errorf("Expected context arguments %v, got %v", expected, args)
+		}
 	}
+}
 
-	if len(mockLogger.warnIn[0].args) != 1 {
-		t.Fatalf(
-			"Expected Warn to be called with 1 argument, got %d",
-			len(mockLogger.warnIn[0].args),
-		)
+func TestContextLogger_Log(t *testing.T) {
+	t.Parallel()
+
+	mockLogger := new(mockLogger)
+
+	logger := decorator.NewContextLogger(mockLogger)
+
+	ctx := logger.PutContext(context.Background(), "traceID", "t-1")
+
+	logger.Log(ctx, cakelog.LevelInfo+1, "log message", nil, "log", 7)
+
+	if len(mockLogger.infoIn) != 1 {
+		t.Fatalf("Expected Info to be called once, got %d calls", len(mockLogger.infoIn))
 	}
 
-	if len(mockLogger.errorIn[0].args) != 1 {
-		t.Fatalf(
-			"Expected Error to be called with 1 argument, got %d",
-			len(mockLogger.errorIn[0].args),
-		)
+	if mockLogger.infoIn[0].msg != "log message" {
+		t.Errorf("Expected Info message to be 'log message', got '%s'", mockLogger.infoIn[0].msg)
+	}
+
+	expected := []any{"log", 7, "traceID", "t-1"}
+
+	if !reflect.DeepEqual(mockLogger.infoIn[0].args, expected) {
+		t.Errorf("Expected Info arguments %v, got %v", expected, mockLogger.infoIn[0].args)
 	}
+}
+
+func TestContextLogger_With(t *testing.T) {
+	t.Parallel()
+
+	mockLogger := new(mockLogger)
+
+	logger := decorator.NewContextLogger(mockLogger)
+	derived := cakelog.With(logger, "service", "api")
 
-	ctxValue1, ok := mockLogger.debugIn[0].args[0].(map[any]any)
+	enricher, ok := derived.(decorator.ContextEnricher)
 	if !ok {
-		t.Fatalf(
-			"Expected Debug argument to be a map[string]any, got '%T'",
-			mockLogger.debugIn[0].args[0],
-		)
+		t.Fatalf("Expected derived logger to implement ContextEnricher, got %T", derived)
 	}
 
-	if len(ctxValue1) != 1 || ctxValue1["key1"] != "value1" {
-		t.Errorf(
-			"Expected Debug context to be map[any]any{\"key1\": \"value1\"}, got '%v'",
-			ctxValue1,
-		)
+	ctx := enricher.PutContext(context.Background(), "requestID", "r-1")
+
+	derived.Info(ctx, "info message", "user", 42)
+	logger.Info(ctx, "plain message")
+
+	if len(mockLogger.infoIn) != 2 {
+		t.Fatalf("Expected Info to be called twice, got %d calls", len(mockLogger.infoIn))
+	}
+
+	fields := cakelog.Normalize(mockLogger.infoIn[0].args...)
+
+	if len(fields) != 3 ||
+		fields[0] != cakelog.NewField("service", "api") ||
+		fields[1] != cakelog.NewField("user", 42) ||
+		fields[2] != cakelog.NewField("requestID", "r-1") {
+		t.Errorf("Unexpected fields of the derived logger: %v", fields)
+	}
+
+	fields = cakelog.Normalize(mockLogger.infoIn[1].args...)
+
+	if len(fields) != 1 || fields[0] != cakelog.NewField("requestID", "r-1") {
+		t.Errorf("Expected the original logger to share context values only, got %v", fields)
+	}
+}
+
+func TestContextLogger_Enabled(t *testing.T) {
+	t.Parallel()
+
+	logger := decorator.NewContextLogger(&mockEnabledLogger{minLevel: cakelog.LevelInfo})
+
+	if logger.Enabled(context.Background(), cakelog.LevelDebug) {
+		t.Error("Expected debug to be disabled")
+	}
+
+	if !logger.Enabled(context.Background(), cakelog.LevelInfo) {
+		t.Error("Expected info to be enabled")
+	}
+}
+
+func TestContextLogger_Shutdown(t *testing.T) {
+	t.Parallel()
+
+	expectedErr := errors.New("close failed")
+	mockLogger := &mockLifecycleLogger{closeErr: expectedErr}
+
+	logger := decorator.NewContextLogger(mockLogger)
+
+	err := cakelog.Shutdown(context.Background(), logger.With("service", "api"))
+
+	if !errors.Is(err, expectedErr) {
+		t.Errorf("Expected error to be '%v', got '%v'", expectedErr, err)
+	}
+
+	if mockLogger.syncs != 1 || mockLogger.closes != 1 {
+		t.Errorf("Expected the underlying logger to be synced and closed once, got %d and %d",
+			mockLogger.syncs, mockLogger.closes)
+	}
+}
+
+func TestContextLogger_FindInChain(t *testing.T) {
+	t.Parallel()
+
+	mockLogger := new(mockLogger)
+	contextLogger := decorator.NewContextLogger(mockLogger)
+	prometheusLogger := decorator.NewPrometheusLogger(contextLogger, decorator.PrometheusLoggerCounter{})
+	logger := decorator.NewSentryLogger(prometheusLogger, decorator.SentryLoggerHub{})
+
+	if logger.Unwrap() != prometheusLogger || prometheusLogger.Unwrap() != contextLogger {
+		t.Fatal("Expected the decorators to unwrap to the loggers they wrap")
+	}
+
+	if contextLogger.Unwrap() != mockLogger {
+		t.Fatal("Expected the ContextLogger to unwrap to the base logger")
 	}
 
-	ctxValue2, ok := mockLogger.infoIn[0].args[0].(map[any]any)
+	enricher, ok := cakelog.Find[decorator.ContextEnricher](logger)
 	if !ok {
-		t.Fatalf(
-			"Expected Info argument to be a map[string]any, got '%T'",
-			mockLogger.infoIn[0].args[0],
-		)
+		t.Fatal("Expected to find the ContextEnricher in the chain")
 	}
 
-	if len(ctxValue2) != 2 || ctxValue2["key1"] != "value1" || ctxValue2["key2"] != "value2" {
-		t.Errorf(
-			"Expected Info context to be map[any]any{\"key1\": \"value1\", \"key2\": \"value2\"}, got '%v'",
-			ctxValue2,
-		)
+	ctx := enricher.PutContext(context.Background(), "requestID", "r-1")
+
+	logger.Info(ctx, "info message")
+
+	if len(mockLogger.infoIn) != 1 {
+		t.Fatalf("Expected Info to be called once, got %d", len(mockLogger.infoIn))
 	}
 
-	ctxValue3, ok := mockLogger.warnIn[0].args[0].(map[any]any)
+	fields := cakelog.Normalize(mockLogger.infoIn[0].args...)
+
+	if len(fields) != 1 || fields[0] != cakelog.NewField("requestID", "r-1") {
+		t.Errorf("Expected the context value to be logged, got %v", fields)
+	}
+}
+
+func TestContextMiddleware(t *testing.T) {
+	t.Parallel()
+
+	mockLogger := new(mockLogger)
+
+	logger := cakelog.Chain(
+		mockLogger,
+		decorator.SentryMiddleware(decorator.SentryLoggerHub{}),
+		decorator.PrometheusMiddleware(decorator.PrometheusLoggerCounter{}),
+		decorator.ContextMiddleware(),
+	)
+
+	sentryLogger, ok := logger.(*decorator.SentryLogger)
 	if !ok {
-		t.Fatalf(
-			"Expected Warn argument to be a map[string]any, got '%T'",
-			mockLogger.warnIn[0].args[0],
-		)
+		t.Fatalf("Expected the first middleware to be the outermost, got %T", logger)
 	}
 
-	if len(ctxValue3) != 3 ||
-		ctxValue3["key1"] != "value1" ||
-		ctxValue3["key2"] != "value2" ||
-		ctxValue3["key3"] != "value3" {
-		t.Errorf(
-			"Expected Warn context to be map[any]any{\"key1\": \"value1\", "+
-				"\"key2\": \"value2\", \"key3\": \"value3\"}, got '%v'",
-			ctxValue3,
-		)
+	prometheusLogger, ok := sentryLogger.Unwrap().(*decorator.PrometheusLogger)
+	if !ok {
+		t.Fatalf("Expected a PrometheusLogger below the SentryLogger, got %T", sentryLogger.Unwrap())
 	}
 
-	ctxValue4, ok := mockLogger.errorIn[0].args[0].(map[any]any)
+	contextLogger, ok := prometheusLogger.Unwrap().(*decorator.ContextLogger)
 	if !ok {
-		t.Fatalf(
-			"Expected Error argument to be a map[string]any, got '%T'",
-			mockLogger.errorIn[0].args[0],
-		)
+		t.Fatalf("Expected a ContextLogger below the PrometheusLogger, got %T", prometheusLogger.Unwrap())
 	}
 
-	if len(ctxValue4) != 4 ||
-		ctxValue4["key1"] != "value1" ||
-		ctxValue4["key2"] != "value2" ||
-		ctxValue4["key3"] != "value3" ||
-		ctxValue4["key4"] != "value4" {
-		t.Errorf(
-			"Expected Error context to be map[any]any{\"key1\": \"value1\", "+
-				"\"key2\": \"value2\", \"key3\": \"value3\", \"key4\": \"value4\"}, got '%v'",
-			ctxValue4,
-		)
+	if contextLogger.Unwrap() != mockLogger {
+		t.Errorf("Expected the ContextLogger to wrap the base logger, got %T", contextLogger.Unwrap())
 	}
 }
@@ -0,0 +1,110 @@
+package decorator
+
+import (
+	"context"
+
+	"github.com/yuppyweb/cakelog"
+)
+
+// Is a cakelog.Logger decorator that drops messages below the level of a cakelog.Leveler.
+// With a cakelog.LevelVar the level can be changed while the logger is used, for any adapter.
+// The underlying logger must itself be at least as verbose as the lowest level the filter will be set to.
+type LevelFilterLogger struct {
+	// Provides the logging methods, which pass every message to Handle as a cakelog.Record.
+	*cakelog.HandlerLogger
+
+	// The underlying logger to which log messages will be forwarded.
+	log cakelog.Logger
+
+	// Provides the lowest level of forwarded messages.
+	leveler cakelog.Leveler
+}
+
+// Creates a new LevelFilterLogger that forwards the messages at or above the level of the leveler
+// to the provided cakelog.Logger.
+func NewLevelFilterLogger(log cakelog.Logger, leveler cakelog.Leveler) *LevelFilterLogger {
+	lfl := &LevelFilterLogger{
+		log:     log,
+		leveler: leveler,
+	}
+	lfl.HandlerLogger = cakelog.NewHandlerLogger(lfl)
+
+	return lfl
+}
+
+// Returns a cakelog.Middleware that wraps a logger in a LevelFilterLogger with the given leveler.
+func LevelFilterMiddleware(leveler cakelog.Leveler) cakelog.Middleware {
+	return func(logger cakelog.Logger) cakelog.Logger {
+		return NewLevelFilterLogger(logger, leveler)
+	}
+}
+
+// Forwards the record to the underlying logger if its level is not below the current level of the leveler.
+func (lfl *LevelFilterLogger) Handle(record cakelog.Record) {
+	if record.Level < lfl.leveler.Level() {
+		return
+	}
+
+	cakelog.Handle(lfl.log, record)
+}
+
+// Reports whether the level passes the filter and the underlying logger would write a message at it.
+func (lfl *LevelFilterLogger) Enabled(ctx context.Context, level cakelog.Level) bool {
+	return level >= lfl.leveler.Level() && cakelog.Enabled(ctx, lfl.log, level)
+}
+
+// Flushes the buffered messages of the underlying logger.
+func (lfl *LevelFilterLogger) Sync(ctx context.Context) error {
+	return cakelog.Sync(ctx, lfl.log)
+}
+
+// Releases the resources of the underlying logger.
+func (lfl *LevelFilterLogger) Close(ctx context.Context) error {
+	return cakelog.Close(ctx, lfl.log)
+}
+
+// Returns the underlying logger.
+func (lfl *LevelFilterLogger) Unwrap() cakelog.Logger {
+	return lfl.log
+}
+
+// Returns a LevelFilterLogger that binds the given arguments to the underlying logger with cakelog.With.
+// The returned logger shares the leveler.
+func (lfl *LevelFilterLogger) With(args ...any) cakelog.Logger {
+	return NewLevelFilterLogger(cakelog.With(lfl.log, args...), lfl.leveler)
+}
+
+// Returns a LevelFilterLogger that nests the arguments in a group of the underlying logger with cakelog.WithGroup.
+// The returned logger shares the leveler.
+func (lfl *LevelFilterLogger) WithGroup(name string) cakelog.Logger {
+	return NewLevelFilterLogger(cakelog.WithGroup(lfl.log, name), lfl.leveler)
+}
+
+var (
+	// Ensures that LevelFilterLogger implements the cakelog.Logger interface.
+	_ cakelog.Logger = (*LevelFilterLogger)(nil)
+
+	// Ensures that LevelFilterLogger implements the cakelog.Handler interface.
+	_ cakelog.Handler = (*LevelFilterLogger)(nil)
+
+	// Ensures that LevelFilterLogger implements the cakelog.LevelLogger interface.
+	_ cakelog.LevelLogger = (*LevelFilterLogger)(nil)
+
+	// Ensures that LevelFilterLogger implements the cakelog.WithLogger interface.
+	_ cakelog.WithLogger = (*LevelFilterLogger)(nil)
+
+	// Ensures that LevelFilterLogger implements the cakelog.GroupLogger interface.
+	_ cakelog.GroupLogger = (*LevelFilterLogger)(nil)
+
+	// Ensures that LevelFilterLogger implements the cakelog.Enabler interface.
+	_ cakelog.Enabler = (*LevelFilterLogger)(nil)
+
+	// Ensures that LevelFilterLogger implements the cakelog.Syncer interface.
+	_ cakelog.Syncer = (*LevelFilterLogger)(nil)
+
+	// Ensures that LevelFilterLogger implements the cakelog.Closer interface.
+	_ cakelog.Closer = (*LevelFilterLogger)(nil)
+
+	// Ensures that LevelFilterLogger implements the cakelog.Unwrapper interface.
+	_ cakelog.Unwrapper = (*LevelFilterLogger)(nil)
+)
@@ -0,0 +1,108 @@
+package decorator_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/yuppyweb/cakelog"
+	"github.com/yuppyweb/cakelog/decorator"
+)
+
+func TestLevelFilterLogger(t *testing.T) {
+	t.Parallel()
+
+	log := new(mockLogger)
+	levelVar := cakelog.NewLevelVar(cakelog.LevelWarn)
+	logger := decorator.NewLevelFilterLogger(log, levelVar)
+
+	logger.Debug(context.Background(), "debug message")
+	logger.Info(context.Background(), "info message")
+	logger.Warn(context.Background(), "warn message")
+	logger.Error(context.Background(), errors.New("error message"))
+
+	if len(log.debugIn) != 0 || len(log.infoIn) != 0 || len(log.warnIn) != 1 || len(log.errorIn) != 1 {
+		t.Errorf("Expected only warn and error messages, got %d, %d, %d, %d",
+			len(log.debugIn), len(log.infoIn), len(log.warnIn), len(log.errorIn))
+	}
+
+	levelVar.Set(cakelog.LevelDebug)
+
+	logger.Debug(context.Background(), "debug message")
+
+	if len(log.debugIn) != 1 {
+		t.Errorf("Expected the debug message to pass after changing the level, got %d", len(log.debugIn))
+	}
+}
+
+func TestLevelFilterLogger_TimedRevert(t *testing.T) {
+	t.Parallel()
+
+	log := new(mockLogger)
+	levelVar := cakelog.NewLevelVar(cakelog.LevelInfo)
+	logger := decorator.NewLevelFilterLogger(log, levelVar)
+
+	levelVar.SetFor(cakelog.LevelDebug, 10*time.Millisecond)
+	logger.Debug(context.Background(), "debug message")
+
+	deadline := time.Now().Add(time.Second)
+
+	for levelVar.Level() != cakelog.LevelInfo && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+
+	logger.Debug(context.Background(), "debug message")
+
+	if len(log.debugIn) != 1 {
+		t.Errorf("Expected only the debug message before the revert, got %d", len(log.debugIn))
+	}
+}
+
+func TestLevelFilterLogger_Enabled(t *testing.T) {
+	t.Parallel()
+
+	logger := decorator.NewLevelFilterLogger(&mockEnabledLogger{minLevel: cakelog.LevelInfo}, cakelog.LevelWarn)
+
+	if logger.Enabled(context.Background(), cakelog.LevelInfo) {
+		t.Error("Expected info to be disabled by the filter")
+	}
+
+	if !logger.Enabled(context.Background(), cakelog.LevelWarn) {
+		t.Error("Expected warn to be enabled")
+	}
+
+	inner := decorator.NewLevelFilterLogger(&mockEnabledLogger{minLevel: cakelog.LevelError}, cakelog.LevelDebug)
+
+	if inner.Enabled(context.Background(), cakelog.LevelWarn) {
+		t.Error("Expected warn to be disabled by the underlying logger")
+	}
+}
+
+func TestLevelFilterLogger_Chain(t *testing.T) {
+	t.Parallel()
+
+	log := new(mockLifecycleLogger)
+	logger := cakelog.Chain(log, decorator.LevelFilterMiddleware(cakelog.LevelInfo))
+
+	derived := cakelog.WithGroup(cakelog.With(logger, "service", "api"), "http")
+
+	if _, ok := derived.(*decorator.LevelFilterLogger); !ok {
+		t.Errorf("Expected With and WithGroup to keep the filter, got %T", derived)
+	}
+
+	derived.Debug(context.Background(), "debug message")
+	derived.Info(context.Background(), "info message")
+
+	if len(log.debugIn) != 0 || len(log.infoIn) != 1 {
+		t.Errorf("Expected only the info message, got %d, %d", len(log.debugIn), len(log.infoIn))
+	}
+
+	if found, ok := cakelog.Find[*mockLifecycleLogger](logger); !ok || found != log {
+		t.Error("Expected Unwrap to return the underlying logger")
+	}
+
+	if err := cakelog.Shutdown(context.Background(), logger); err != nil || log.syncs != 1 || log.closes != 1 {
+		t.Errorf("Expected Shutdown to reach the underlying logger, got %v, %d, %d", err, log.syncs, log.closes)
+	}
+}
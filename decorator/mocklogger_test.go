@@ -58,3 +58,41 @@ func (ml *mockLogger) Error(ctx context.Context, err error, args ...any) {
 }
 
 var _ cakelog.Logger = (*mockLogger)(nil)
+
+type mockEnabledLogger struct {
+	mockLogger
+
+	minLevel cakelog.Level
+}
+
+func (ml *mockEnabledLogger) Enabled(_ context.Context, level cakelog.Level) bool {
+	return level >= ml.minLevel
+}
+
+var _ cakelog.Enabler = (*mockEnabledLogger)(nil)
+
+type mockLifecycleLogger struct {
+	mockLogger
+
+	syncs    int
+	closes   int
+	syncErr  error
+	closeErr error
+}
+
+func (ml *mockLifecycleLogger) Sync(context.Context) error {
+	ml.syncs++
+
+	return ml.syncErr
+}
+
+func (ml *mockLifecycleLogger) Close(context.Context) error {
+	ml.closes++
+
+	return ml.closeErr
+}
+
+var (
+	_ cakelog.Syncer = (*mockLifecycleLogger)(nil)
+	_ cakelog.Closer = (*mockLifecycleLogger)(nil)
+)
@@ -0,0 +1,183 @@
+package decorator
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/yuppyweb/cakelog"
+)
+
+// Is a single destination of a MultiLogger.
+type MultiLoggerSink struct {
+	// The logger to which messages are forwarded.
+	Logger cakelog.Logger
+
+	// The lowest level of messages forwarded to the logger.
+	// The zero value is cakelog.LevelInfo, following the default of log/slog.
+	MinLevel cakelog.Level
+}
+
+// MultiLogger is a decorator that forwards every message to several loggers.
+// Sinks are called one after another in the order they were given,
+// and a panic in one sink is recovered so the remaining sinks still receive the message.
+type MultiLogger struct {
+	// Provides the logging methods, which pass every message to Handle as a cakelog.Record.
+	*cakelog.HandlerLogger
+
+	// The destinations of log messages.
+	sinks []MultiLoggerSink
+
+	// Called with the recovered value when a sink panics. Panics are dropped if it is nil.
+	PanicHandler func(ctx context.Context, logger cakelog.Logger, recovered any)
+}
+
+// Creates a new MultiLogger that forwards messages to the provided sinks in order.
+// Sinks without a logger are skipped.
+func NewMultiLogger(sinks ...MultiLoggerSink) *MultiLogger {
+	return newMultiLogger(slices.DeleteFunc(slices.Clone(sinks), func(sink MultiLoggerSink) bool {
+		return sink.Logger == nil
+	}), nil)
+}
+
+// Forwards the record to every sink whose minimum level is not above the level of the record, in order.
+// Attributes added by one sink are not seen by the others.
+func (ml *MultiLogger) Handle(record cakelog.Record) {
+	for _, sink := range ml.sinks {
+		if record.Level < sink.MinLevel {
+			continue
+		}
+
+		ml.forward(record.Context, sink.Logger, func() {
+			cakelog.Handle(sink.Logger, record)
+		})
+	}
+}
+
+// Reports whether at least one sink would write a message at the given level.
+func (ml *MultiLogger) Enabled(ctx context.Context, level cakelog.Level) bool {
+	for _, sink := range ml.sinks {
+		if level >= sink.MinLevel && cakelog.Enabled(ctx, sink.Logger, level) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Flushes the buffered messages of every sink and joins their errors.
+func (ml *MultiLogger) Sync(ctx context.Context) error {
+	errs := make([]error, 0, len(ml.sinks))
+
+	for _, sink := range ml.sinks {
+		errs = append(errs, cakelog.Sync(ctx, sink.Logger))
+	}
+
+	return errors.Join(errs...)
+}
+
+// Releases the resources of every sink and joins their errors.
+func (ml *MultiLogger) Close(ctx context.Context) error {
+	errs := make([]error, 0, len(ml.sinks))
+
+	for _, sink := range ml.sinks {
+		errs = append(errs, cakelog.Close(ctx, sink.Logger))
+	}
+
+	return errors.Join(errs...)
+}
+
+// Returns a MultiLogger that binds the given arguments to every sink with cakelog.With.
+// The returned logger keeps the minimum levels and the panic handler.
+func (ml *MultiLogger) With(args ...any) cakelog.Logger {
+	return ml.derive(func(logger cakelog.Logger) cakelog.Logger {
+		return cakelog.With(logger, args...)
+	})
+}
+
+// Returns a MultiLogger that nests the arguments in a group of every sink with cakelog.WithGroup.
+// The returned logger keeps the minimum levels and the panic handler.
+func (ml *MultiLogger) WithGroup(name string) cakelog.Logger {
+	return ml.derive(func(logger cakelog.Logger) cakelog.Logger {
+		return cakelog.WithGroup(logger, name)
+	})
+}
+
+// Returns the loggers of all sinks in order.
+func (ml *MultiLogger) Unwrap() []cakelog.Logger {
+	loggers := make([]cakelog.Logger, 0, len(ml.sinks))
+
+	for _, sink := range ml.sinks {
+		loggers = append(loggers, sink.Logger)
+	}
+
+	return loggers
+}
+
+// Helper method to call a sink and recover from its panic.
+func (ml *MultiLogger) forward(ctx context.Context, logger cakelog.Logger, call func()) {
+	defer func() {
+		if recovered := recover(); recovered != nil && ml.PanicHandler != nil {
+			ml.PanicHandler(ctx, logger, recovered)
+		}
+	}()
+
+	call()
+}
+
+// Helper method to create a MultiLogger with every sink logger replaced by the result of the function.
+func (ml *MultiLogger) derive(fn func(cakelog.Logger) cakelog.Logger) *MultiLogger {
+	sinks := make([]MultiLoggerSink, 0, len(ml.sinks))
+
+	for _, sink := range ml.sinks {
+		sinks = append(sinks, MultiLoggerSink{
+			Logger:   fn(sink.Logger),
+			MinLevel: sink.MinLevel,
+		})
+	}
+
+	return newMultiLogger(sinks, ml.PanicHandler)
+}
+
+// Helper function to create a MultiLogger whose logging methods pass records to its Handle method.
+func newMultiLogger(
+	sinks []MultiLoggerSink,
+	panicHandler func(ctx context.Context, logger cakelog.Logger, recovered any),
+) *MultiLogger {
+	ml := &MultiLogger{
+		sinks:        sinks,
+		PanicHandler: panicHandler,
+	}
+	ml.HandlerLogger = cakelog.NewHandlerLogger(ml)
+
+	return ml
+}
+
+var (
+	// Ensures that MultiLogger implements the cakelog.Logger interface.
+	_ cakelog.Logger = (*MultiLogger)(nil)
+
+	// Ensures that MultiLogger implements the cakelog.Handler interface.
+	_ cakelog.Handler = (*MultiLogger)(nil)
+
+	// Ensures that MultiLogger implements the cakelog.LevelLogger interface.
+	_ cakelog.LevelLogger = (*MultiLogger)(nil)
+
+	// Ensures that MultiLogger implements the cakelog.WithLogger interface.
+	_ cakelog.WithLogger = (*MultiLogger)(nil)
+
+	// Ensures that MultiLogger implements the cakelog.GroupLogger interface.
+	_ cakelog.GroupLogger = (*MultiLogger)(nil)
+
+	// Ensures that MultiLogger implements the cakelog.Enabler interface.
+	_ cakelog.Enabler = (*MultiLogger)(nil)
+
+	// Ensures that MultiLogger implements the cakelog.Syncer interface.
+	_ cakelog.Syncer = (*MultiLogger)(nil)
+
+	// Ensures that MultiLogger implements the cakelog.Closer interface.
+	_ cakelog.Closer = (*MultiLogger)(nil)
+
+	// Ensures that MultiLogger implements the cakelog.MultiUnwrapper interface.
+	_ cakelog.MultiUnwrapper = (*MultiLogger)(nil)
+)
@@ -0,0 +1,186 @@
+package decorator_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/yuppyweb/cakelog"
+	"github.com/yuppyweb/cakelog/decorator"
+)
+
+type mockPanicLogger struct {
+	mockLogger
+}
+
+func (ml *mockPanicLogger) Info(context.Context, string, ...any) {
+	panic("sink failed")
+}
+
+func TestMultiLogger_Levels(t *testing.T) {
+	t.Parallel()
+
+	debugLogger := new(mockLogger)
+	warnLogger := new(mockLogger)
+
+	logger := decorator.NewMultiLogger(
+		decorator.MultiLoggerSink{Logger: debugLogger, MinLevel: cakelog.LevelDebug},
+		decorator.MultiLoggerSink{Logger: nil},
+		decorator.MultiLoggerSink{Logger: warnLogger, MinLevel: cakelog.LevelWarn},
+	)
+
+	expectedErr := errors.New("error message")
+
+	logger.Debug(context.Background(), "debug message", "key", "value")
+	logger.Info(context.Background(), "info message")
+	logger.Warn(context.Background(), "warn message")
+	logger.Error(context.Background(), expectedErr)
+
+	if len(debugLogger.debugIn) != 1 || len(debugLogger.infoIn) != 1 ||
+		len(debugLogger.warnIn) != 1 || len(debugLogger.errorIn) != 1 {
+		t.Errorf("Expected the debug sink to receive every message, got %+v", debugLogger)
+	}
+
+	if len(warnLogger.debugIn) != 0 || len(warnLogger.infoIn) != 0 {
+		t.Errorf("Expected the warn sink to skip debug and info messages, got %+v", warnLogger)
+	}
+
+	if len(warnLogger.warnIn) != 1 || len(warnLogger.errorIn) != 1 {
+		t.Fatalf("Expected the warn sink to receive warn and error messages, got %+v", warnLogger)
+	}
+
+	if !errors.Is(warnLogger.errorIn[0].err, expectedErr) {
+		t.Errorf("Expected Error to be '%v', got '%v'", expectedErr, warnLogger.errorIn[0].err)
+	}
+
+	if len(debugLogger.debugIn[0].args) != 2 || debugLogger.debugIn[0].args[1] != "value" {
+		t.Errorf("Expected Debug args to be forwarded, got %v", debugLogger.debugIn[0].args)
+	}
+}
+
+func TestMultiLogger_Panic(t *testing.T) {
+	t.Parallel()
+
+	panicLogger := new(mockPanicLogger)
+	mockLogger := new(mockLogger)
+
+	var recovered []any
+
+	logger := decorator.NewMultiLogger(
+		decorator.MultiLoggerSink{Logger: panicLogger},
+		decorator.MultiLoggerSink{Logger: mockLogger},
+	)
+	logger.PanicHandler = func(_ context.Context, sink cakelog.Logger, value any) {
+		if sink != panicLogger {
+			t.Errorf("Expected the panicking sink to be reported, got %T", sink)
+		}
+
+		recovered = append(recovered, value)
+	}
+
+	logger.Info(context.Background(), "info message")
+
+	if len(recovered) != 1 || recovered[0] != "sink failed" {
+		t.Errorf("Expected the panic to be recovered, got %v", recovered)
+	}
+
+	if len(mockLogger.infoIn) != 1 {
+		t.Errorf("Expected the next sink to receive the message, got %d calls", len(mockLogger.infoIn))
+	}
+}
+
+func TestMultiLogger_Enabled(t *testing.T) {
+	t.Parallel()
+
+	logger := decorator.NewMultiLogger(
+		decorator.MultiLoggerSink{
+			Logger:   &mockEnabledLogger{minLevel: cakelog.LevelError},
+			MinLevel: cakelog.LevelDebug,
+		},
+		decorator.MultiLoggerSink{Logger: new(mockLogger), MinLevel: cakelog.LevelWarn},
+	)
+
+	if logger.Enabled(context.Background(), cakelog.LevelInfo) {
+		t.Error("Expected info to be disabled")
+	}
+
+	if !logger.Enabled(context.Background(), cakelog.LevelWarn) {
+		t.Error("Expected warn to be enabled")
+	}
+}
+
+func TestMultiLogger_WithAndShutdown(t *testing.T) {
+	t.Parallel()
+
+	expectedErr := errors.New("sync failed")
+	first := &mockLifecycleLogger{syncErr: expectedErr}
+	second := new(mockLifecycleLogger)
+
+	logger := decorator.NewMultiLogger(
+		decorator.MultiLoggerSink{Logger: first},
+		decorator.MultiLoggerSink{Logger: second, MinLevel: cakelog.LevelWarn},
+	)
+
+	derived := cakelog.WithGroup(cakelog.With(logger, "service", "api"), "http")
+
+	derived.Warn(context.Background(), "warn message", "status", 500)
+
+	for _, sink := range []*mockLifecycleLogger{first, second} {
+		if len(sink.warnIn) != 1 {
+			t.Fatalf("Expected Warn to be called once, got %d", len(sink.warnIn))
+		}
+
+		fields := cakelog.Normalize(sink.warnIn[0].args...)
+
+		if len(fields) != 2 || fields[0] != cakelog.NewField("service", "api") || fields[1].Key != "http" {
+			t.Errorf("Expected the bound and grouped fields, got %v", fields)
+		}
+	}
+
+	if err := cakelog.Shutdown(context.Background(), derived); !errors.Is(err, expectedErr) {
+		t.Errorf("Expected error to be '%v', got '%v'", expectedErr, err)
+	}
+
+	if first.syncs != 1 || first.closes != 1 || second.syncs != 1 || second.closes != 1 {
+		t.Error("Expected every sink to be synced and closed once")
+	}
+
+	loggers := logger.Unwrap()
+
+	if len(loggers) != 2 || loggers[0] != first || loggers[1] != second {
+		t.Errorf("Expected the sink loggers in order, got %v", loggers)
+	}
+}
+
+func TestMultiLogger_LazyArgs(t *testing.T) {
+	t.Parallel()
+
+	first := new(mockLogger)
+	second := new(mockLogger)
+	logger := decorator.NewMultiLogger(
+		decorator.MultiLoggerSink{Logger: first},
+		decorator.MultiLoggerSink{Logger: second},
+	)
+	calls := 0
+
+	logger.Info(context.Background(), "info message", cakelog.LazyField("body", func() any {
+		calls++
+
+		return "payload"
+	}))
+
+	if len(first.infoIn) != 1 || len(second.infoIn) != 1 {
+		t.Fatalf("Expected both sinks to receive the message, got %d and %d", len(first.infoIn), len(second.infoIn))
+	}
+
+	if calls != 0 {
+		t.Errorf("Expected the lazy value not to be resolved by the decorator, got %d calls", calls)
+	}
+
+	cakelog.Resolve(first.infoIn[0].args[1])
+	cakelog.Resolve(second.infoIn[0].args[1])
+
+	if calls != 1 {
+		t.Errorf("Expected the lazy value to be resolved once for both sinks, got %d calls", calls)
+	}
+}
@@ -0,0 +1,112 @@
+package decorator
+
+import (
+	"context"
+
+	"github.com/yuppyweb/cakelog"
+)
+
+// Is a cakelog.Logger decorator that drops messages below the level a cakelog.LevelRegistry gives to the name
+// of the logger they were sent through, as reported by cakelog.LoggerName. It is placed below the loggers
+// created with cakelog.Named, usually right above the adapter, which must itself be at least as verbose
+// as the lowest level of the rules.
+type NamedLevelLogger struct {
+	// Provides the logging methods, which pass every message to Handle as a cakelog.Record.
+	*cakelog.HandlerLogger
+
+	// The underlying logger to which log messages will be forwarded.
+	log cakelog.Logger
+
+	// Provides the lowest level of forwarded messages for every logger name.
+	registry *cakelog.LevelRegistry
+}
+
+// Creates a new NamedLevelLogger that forwards the messages at or above the level the registry
+// gives to their logger name to the provided cakelog.Logger.
+func NewNamedLevelLogger(log cakelog.Logger, registry *cakelog.LevelRegistry) *NamedLevelLogger {
+	nll := &NamedLevelLogger{
+		log:      log,
+		registry: registry,
+	}
+	nll.HandlerLogger = cakelog.NewHandlerLogger(nll)
+
+	return nll
+}
+
+// Returns a cakelog.Middleware that wraps a logger in a NamedLevelLogger with the given registry.
+func NamedLevelMiddleware(registry *cakelog.LevelRegistry) cakelog.Middleware {
+	return func(logger cakelog.Logger) cakelog.Logger {
+		return NewNamedLevelLogger(logger, registry)
+	}
+}
+
+// Forwards the record to the underlying logger if its level is enabled for the name of its logger.
+func (nll *NamedLevelLogger) Handle(record cakelog.Record) {
+	if !nll.registry.Enabled(cakelog.LoggerName(record.Context), record.Level) {
+		return
+	}
+
+	cakelog.Handle(nll.log, record)
+}
+
+// Reports whether the level is enabled for the name of the logger checking it
+// and the underlying logger would write a message at it.
+func (nll *NamedLevelLogger) Enabled(ctx context.Context, level cakelog.Level) bool {
+	return nll.registry.Enabled(cakelog.LoggerName(ctx), level) && cakelog.Enabled(ctx, nll.log, level)
+}
+
+// Flushes the buffered messages of the underlying logger.
+func (nll *NamedLevelLogger) Sync(ctx context.Context) error {
+	return cakelog.Sync(ctx, nll.log)
+}
+
+// Releases the resources of the underlying logger.
+func (nll *NamedLevelLogger) Close(ctx context.Context) error {
+	return cakelog.Close(ctx, nll.log)
+}
+
+// Returns the underlying logger.
+func (nll *NamedLevelLogger) Unwrap() cakelog.Logger {
+	return nll.log
+}
+
+// Returns a NamedLevelLogger that binds the given arguments to the underlying logger with cakelog.With.
+// The returned logger shares the registry.
+func (nll *NamedLevelLogger) With(args ...any) cakelog.Logger {
+	return NewNamedLevelLogger(cakelog.With(nll.log, args...), nll.registry)
+}
+
+// Returns a NamedLevelLogger that nests the arguments in a group of the underlying logger with cakelog.WithGroup.
+// The returned logger shares the registry.
+func (nll *NamedLevelLogger) WithGroup(name string) cakelog.Logger {
+	return NewNamedLevelLogger(cakelog.WithGroup(nll.log, name), nll.registry)
+}
+
+var (
+	// Ensures that NamedLevelLogger implements the cakelog.Logger interface.
+	_ cakelog.Logger = (*NamedLevelLogger)(nil)
+
+	// Ensures that NamedLevelLogger implements the cakelog.Handler interface.
+	_ cakelog.Handler = (*NamedLevelLogger)(nil)
+
+	// Ensures that NamedLevelLogger implements the cakelog.LevelLogger interface.
+	_ cakelog.LevelLogger = (*NamedLevelLogger)(nil)
+
+	// Ensures that NamedLevelLogger implements the cakelog.WithLogger interface.
+	_ cakelog.WithLogger = (*NamedLevelLogger)(nil)
+
+	// Ensures that NamedLevelLogger implements the cakelog.GroupLogger interface.
+	_ cakelog.GroupLogger = (*NamedLevelLogger)(nil)
+
+	// Ensures that NamedLevelLogger implements the cakelog.Enabler interface.
+	_ cakelog.Enabler = (*NamedLevelLogger)(nil)
+
+	// Ensures that NamedLevelLogger implements the cakelog.Syncer interface.
+	_ cakelog.Syncer = (*NamedLevelLogger)(nil)
+
+	// Ensures that NamedLevelLogger implements the cakelog.Closer interface.
+	_ cakelog.Closer = (*NamedLevelLogger)(nil)
+
+	// Ensures that NamedLevelLogger implements the cakelog.Unwrapper interface.
+	_ cakelog.Unwrapper = (*NamedLevelLogger)(nil)
+)
@@ -0,0 +1,78 @@
+package decorator_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/yuppyweb/cakelog"
+	"github.com/yuppyweb/cakelog/decorator"
+)
+
+func TestNamedLevelLogger(t *testing.T) {
+	t.Parallel()
+
+	rules, err := cakelog.ParseLevelRules("db.*=debug,http=warn")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	log := new(mockLogger)
+	root := decorator.NewNamedLevelLogger(log, cakelog.NewLevelRegistry(cakelog.LevelInfo, rules...))
+
+	cakelog.Named(root, "db.pool").Debug(context.Background(), "db debug")
+	cakelog.Named(root, "http").Info(context.Background(), "http info")
+	cakelog.Named(root, "http").Warn(context.Background(), "http warn")
+	root.Debug(context.Background(), "root debug")
+	root.Info(context.Background(), "root info")
+
+	if len(log.debugIn) != 1 || log.debugIn[0].msg != "db debug" {
+		t.Errorf("Expected only the debug message of db.pool, got %v", log.debugIn)
+	}
+
+	if len(log.infoIn) != 1 || log.infoIn[0].msg != "root info" {
+		t.Errorf("Expected only the info message of the root logger, got %v", log.infoIn)
+	}
+
+	if len(log.warnIn) != 1 || log.warnIn[0].args[0] != cakelog.LoggerNameKey || log.warnIn[0].args[1] != "http" {
+		t.Errorf("Expected the warn message of http with its name, got %v", log.warnIn)
+	}
+}
+
+func TestNamedLevelLogger_Enabled(t *testing.T) {
+	t.Parallel()
+
+	registry := cakelog.NewLevelRegistry(cakelog.LevelWarn, cakelog.LevelRule{Pattern: "db", Level: cakelog.LevelDebug})
+	root := decorator.NewNamedLevelLogger(&mockEnabledLogger{minLevel: cakelog.LevelInfo}, registry)
+
+	if cakelog.Enabled(context.Background(), root, cakelog.LevelInfo) {
+		t.Error("Expected info to be disabled for unnamed loggers")
+	}
+
+	if !cakelog.Enabled(context.Background(), cakelog.Named(root, "db"), cakelog.LevelInfo) {
+		t.Error("Expected info to be enabled for db")
+	}
+
+	if cakelog.Enabled(context.Background(), cakelog.Named(root, "db"), cakelog.LevelDebug) {
+		t.Error("Expected debug to be disabled by the underlying logger")
+	}
+}
+
+func TestNamedLevelLogger_With(t *testing.T) {
+	t.Parallel()
+
+	log := new(mockLogger)
+	registry := cakelog.NewLevelRegistry(cakelog.LevelWarn)
+	logger := decorator.NamedLevelMiddleware(registry)(log)
+
+	cakelog.With(logger, "key", "value").Info(context.Background(), "info message")
+	cakelog.WithGroup(logger, "group").Warn(context.Background(), "warn message")
+
+	if len(log.infoIn) != 0 || len(log.warnIn) != 1 {
+		t.Errorf("Expected derived loggers to keep filtering, got %d, %d", len(log.infoIn), len(log.warnIn))
+	}
+
+	if found, ok := cakelog.Find[*decorator.NamedLevelLogger](cakelog.With(logger, "key", "value")); !ok ||
+		found.Unwrap() == nil {
+		t.Error("Expected the derived logger to be a NamedLevelLogger")
+	}
+}
@@ -9,6 +9,9 @@ import (
 
 // Holds Prometheus counters for each log level.
 type PrometheusLoggerCounter struct {
+	// Counter for trace log messages. If it is nil, trace messages are counted by the debug counter.
+	Trace prometheus.Counter
+
 	// Counter for debug log messages.
 	Debug prometheus.Counter
 
@@ -20,10 +23,19 @@ type PrometheusLoggerCounter struct {
 
 	// Counter for error log messages.
 	Error prometheus.Counter
+
+	// Counter for fatal log messages. If it is nil, fatal messages are counted by the error counter.
+	Fatal prometheus.Counter
+
+	// Counter for panic log messages. If it is nil, panic messages are counted like fatal messages.
+	Panic prometheus.Counter
 }
 
 // Is a cakelog.Logger decorator that increments Prometheus counters for each log level.
 type PrometheusLogger struct {
+	// Provides the logging methods, which pass every message to Handle as a cakelog.Record.
+	*cakelog.HandlerLogger
+
 	// The underlying logger to which log messages will be forwarded.
 	logger cakelog.Logger
 
@@ -34,47 +46,116 @@ type PrometheusLogger struct {
 // Creates a new PrometheusLogger that wraps the provided cakelog.Logger
 // and uses the given PrometheusLoggerCounter to track log levels.
 func NewPrometheusLogger(logger cakelog.Logger, counter PrometheusLoggerCounter) *PrometheusLogger {
-	return &PrometheusLogger{
+	pl := &PrometheusLogger{
 		logger:  logger,
 		counter: counter,
 	}
-}
+	pl.HandlerLogger = cakelog.NewHandlerLogger(pl)
 
-// Sends a debug message to the underlying logger and increments the debug counter if it is set.
-func (pl *PrometheusLogger) Debug(ctx context.Context, msg string, args ...any) {
-	pl.logger.Debug(ctx, msg, args...)
+	return pl
+}
 
-	if pl.counter.Debug != nil {
-		pl.counter.Debug.Inc()
+// Returns a cakelog.Middleware that wraps a logger in a PrometheusLogger with the given counters.
+func PrometheusMiddleware(counter PrometheusLoggerCounter) cakelog.Middleware {
+	return func(logger cakelog.Logger) cakelog.Logger {
+		return NewPrometheusLogger(logger, counter)
 	}
 }
 
-// Sends an info message to the underlying logger and increments the info counter if it is set.
-func (pl *PrometheusLogger) Info(ctx context.Context, msg string, args ...any) {
-	pl.logger.Info(ctx, msg, args...)
+// Forwards the record to the underlying logger
+// and increments the counter of the closest level if it is set.
+func (pl *PrometheusLogger) Handle(record cakelog.Record) {
+	cakelog.Handle(pl.logger, record)
 
-	if pl.counter.Info != nil {
-		pl.counter.Info.Inc()
+	if counter := pl.counter.forLevel(record.Level); counter != nil {
+		counter.Inc()
 	}
 }
 
-// Sends a warning message to the underlying logger and increments the warn counter if it is set.
-func (pl *PrometheusLogger) Warn(ctx context.Context, msg string, args ...any) {
-	pl.logger.Warn(ctx, msg, args...)
+// Reports whether a message at the given level would be counted or written by the underlying logger.
+// A level with a counter is always enabled, so the counter sees every message even if the logger discards it.
+func (pl *PrometheusLogger) Enabled(ctx context.Context, level cakelog.Level) bool {
+	return pl.counter.forLevel(level) != nil || cakelog.Enabled(ctx, pl.logger, level)
+}
 
-	if pl.counter.Warn != nil {
-		pl.counter.Warn.Inc()
-	}
+// Flushes the buffered messages of the underlying logger.
+func (pl *PrometheusLogger) Sync(ctx context.Context) error {
+	return cakelog.Sync(ctx, pl.logger)
+}
+
+// Releases the resources of the underlying logger.
+// The counters are owned by the caller and stay registered.
+func (pl *PrometheusLogger) Close(ctx context.Context) error {
+	return cakelog.Close(ctx, pl.logger)
+}
+
+// Returns the underlying logger.
+func (pl *PrometheusLogger) Unwrap() cakelog.Logger {
+	return pl.logger
 }
 
-// Sends an error message to the underlying logger and increments the error counter if it is set.
-func (pl *PrometheusLogger) Error(ctx context.Context, err error, args ...any) {
-	pl.logger.Error(ctx, err, args...)
+// Returns a PrometheusLogger that binds the given arguments to the underlying logger with cakelog.With.
+// The returned logger increments the same counters.
+func (pl *PrometheusLogger) With(args ...any) cakelog.Logger {
+	return NewPrometheusLogger(cakelog.With(pl.logger, args...), pl.counter)
+}
+
+// Returns a PrometheusLogger that nests the arguments in a group of the underlying logger with cakelog.WithGroup.
+// The returned logger increments the same counters.
+func (pl *PrometheusLogger) WithGroup(name string) cakelog.Logger {
+	return NewPrometheusLogger(cakelog.WithGroup(pl.logger, name), pl.counter)
+}
 
-	if pl.counter.Error != nil {
-		pl.counter.Error.Inc()
+// Helper method to pick the counter for the given level.
+// Levels between the known ones are counted by the closest lower level.
+// The trace level falls back to the debug level if it is not set,
+// and fatal and panic levels fall back to the closest lower level that is set, down to the error level.
+func (plc PrometheusLoggerCounter) forLevel(level cakelog.Level) prometheus.Counter {
+	switch {
+	case level < cakelog.LevelDebug && plc.Trace != nil:
+		return plc.Trace
+	case level < cakelog.LevelInfo:
+		return plc.Debug
+	case level < cakelog.LevelWarn:
+		return plc.Info
+	case level < cakelog.LevelError:
+		return plc.Warn
+	case level < cakelog.LevelFatal:
+		return plc.Error
+	case level >= cakelog.LevelPanic && plc.Panic != nil:
+		return plc.Panic
+	case plc.Fatal != nil:
+		return plc.Fatal
+	default:
+		return plc.Error
 	}
 }
 
-// Ensures that PrometheusLogger implements the cakelog.Logger interface.
-var _ cakelog.Logger = (*PrometheusLogger)(nil)
+var (
+	// Ensures that PrometheusLogger implements the cakelog.Logger interface.
+	_ cakelog.Logger = (*PrometheusLogger)(nil)
+
+	// Ensures that PrometheusLogger implements the cakelog.Handler interface.
+	_ cakelog.Handler = (*PrometheusLogger)(nil)
+
+	// Ensures that PrometheusLogger implements the cakelog.LevelLogger interface.
+	_ cakelog.LevelLogger = (*PrometheusLogger)(nil)
+
+	// Ensures that PrometheusLogger implements the cakelog.WithLogger interface.
+	_ cakelog.WithLogger = (*PrometheusLogger)(nil)
+
+	// Ensures that PrometheusLogger implements the cakelog.GroupLogger interface.
+	_ cakelog.GroupLogger = (*PrometheusLogger)(nil)
+
+	// Ensures that PrometheusLogger implements the cakelog.Enabler interface.
+	_ cakelog.Enabler = (*PrometheusLogger)(nil)
+
+	// Ensures that PrometheusLogger implements the cakelog.Syncer interface.
+	_ cakelog.Syncer = (*PrometheusLogger)(nil)
+
+	// Ensures that PrometheusLogger implements the cakelog.Closer interface.
+	_ cakelog.Closer = (*PrometheusLogger)(nil)
+
+	// Ensures that PrometheusLogger implements the cakelog.Unwrapper interface.
+	_ cakelog.Unwrapper = (*PrometheusLogger)(nil)
+)
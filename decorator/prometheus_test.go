@@ -7,6 +7,7 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 	io_prometheus_client "github.com/prometheus/client_model/go"
+	"github.com/yuppyweb/cakelog"
 	"github.com/yuppyweb/cakelog/decorator"
 )
 
@@ -440,3 +441,229 @@ func TestPrometheusLogger_WithoutCounter(t *testing.T) {
 		t.Errorf("Expected Error arguments to be nil, got %v", mockLogger.errorIn[0].args)
 	}
 }
+
+func TestPrometheusLogger_LogCustomLevels(t *testing.T) {
+	t.Parallel()
+
+	debugCounter := new(mockPrometheusCounter)
+	infoCounter := new(mockPrometheusCounter)
+	warnCounter := new(mockPrometheusCounter)
+	errorCounter := new(mockPrometheusCounter)
+	mockLogger := new(mockLogger)
+
+	logger := decorator.NewPrometheusLogger(mockLogger, decorator.PrometheusLoggerCounter{
+		Debug: debugCounter,
+		Info:  infoCounter,
+		Warn:  warnCounter,
+		Error: errorCounter,
+	})
+
+	logger.Log(context.Background(), cakelog.LevelDebug-2, "very verbose message", nil)
+	logger.Log(context.Background(), cakelog.LevelInfo+1, "info message", nil)
+	logger.Log(context.Background(), cakelog.LevelWarn+3, "warn message", nil)
+	logger.Log(context.Background(), cakelog.LevelError+1, "error message", context.Canceled)
+
+	if len(mockLogger.debugIn) != 1 || len(mockLogger.infoIn) != 1 ||
+		len(mockLogger.warnIn) != 1 || len(mockLogger.errorIn) != 1 {
+		t.Fatalf(
+			"Expected each method to be called once, got %d, %d, %d, %d",
+			len(mockLogger.debugIn),
+			len(mockLogger.infoIn),
+			len(mockLogger.warnIn),
+			len(mockLogger.errorIn),
+		)
+	}
+
+	if !errors.Is(mockLogger.errorIn[0].err, context.Canceled) {
+		t.Errorf("Expected Error to wrap context.Canceled, got '%v'", mockLogger.errorIn[0].err)
+	}
+
+	for name, counter := range map[string]*mockPrometheusCounter{
+		"debug": debugCounter,
+		"info":  infoCounter,
+		"warn":  warnCounter,
+		"error": errorCounter,
+	} {
+		if counter.inc != 1 {
+			t.Errorf("Expected %s counter to be incremented once, got %f", name, counter.inc)
+		}
+	}
+}
+
+func TestPrometheusLogger_WithGroup(t *testing.T) {
+	t.Parallel()
+
+	infoCounter := new(mockPrometheusCounter)
+	mockLogger := new(mockLogger)
+
+	logger := decorator.NewPrometheusLogger(mockLogger, decorator.PrometheusLoggerCounter{
+		Info: infoCounter,
+	})
+
+	derived := cakelog.WithGroup(logger, "http")
+
+	if _, ok := derived.(*decorator.PrometheusLogger); !ok {
+		t.Fatalf("Expected derived logger to be a PrometheusLogger, got %T", derived)
+	}
+
+	derived.Info(context.Background(), "info message", "status", 200)
+
+	if infoCounter.inc != 1 {
+		t.Errorf("Expected info counter to be incremented once, got %f", infoCounter.inc)
+	}
+
+	fields := cakelog.Normalize(mockLogger.infoIn[0].args...)
+
+	if len(fields) != 1 || fields[0].Key != "http" {
+		t.Errorf("Expected arguments to be grouped under 'http', got %v", fields)
+	}
+}
+
+func TestPrometheusLogger_Enabled(t *testing.T) {
+	t.Parallel()
+
+	logger := decorator.NewPrometheusLogger(
+		&mockEnabledLogger{minLevel: cakelog.LevelError},
+		decorator.PrometheusLoggerCounter{Warn: new(mockPrometheusCounter)},
+	)
+
+	if logger.Enabled(context.Background(), cakelog.LevelInfo) {
+		t.Error("Expected info without a counter to be disabled")
+	}
+
+	if !logger.Enabled(context.Background(), cakelog.LevelWarn) {
+		t.Error("Expected warn with a counter to be enabled")
+	}
+
+	if !logger.Enabled(context.Background(), cakelog.LevelError) {
+		t.Error("Expected error enabled by the underlying logger to be enabled")
+	}
+}
+
+func TestPrometheusLogger_Shutdown(t *testing.T) {
+	t.Parallel()
+
+	expectedErr := errors.New("sync failed")
+	mockLogger := &mockLifecycleLogger{syncErr: expectedErr}
+
+	logger := decorator.NewPrometheusLogger(mockLogger, decorator.PrometheusLoggerCounter{})
+
+	err := cakelog.Shutdown(context.Background(), logger)
+
+	if !errors.Is(err, expectedErr) {
+		t.Errorf("Expected error to be '%v', got '%v'", expectedErr, err)
+	}
+
+	if mockLogger.syncs != 1 || mockLogger.closes != 1 {
+		t.Errorf("Expected the underlying logger to be synced and closed once, got %d and %d",
+			mockLogger.syncs, mockLogger.closes)
+	}
+}
+
+func TestPrometheusLogger_FatalAndPanicCounters(t *testing.T) {
+	t.Parallel()
+
+	errorCounter := new(mockPrometheusCounter)
+	fatalCounter := new(mockPrometheusCounter)
+	panicCounter := new(mockPrometheusCounter)
+
+	withoutFatal := decorator.NewPrometheusLogger(new(mockLogger), decorator.PrometheusLoggerCounter{
+		Error: errorCounter,
+	})
+
+	withoutFatal.Log(context.Background(), cakelog.LevelFatal, "fatal message", nil)
+	withoutFatal.Log(context.Background(), cakelog.LevelPanic, "panic message", nil)
+
+	if errorCounter.inc != 2 {
+		t.Errorf("Expected fatal and panic messages to fall back to the error counter, got %f", errorCounter.inc)
+	}
+
+	withFatal := decorator.NewPrometheusLogger(new(mockLogger), decorator.PrometheusLoggerCounter{
+		Error: errorCounter,
+		Fatal: fatalCounter,
+	})
+
+	withFatal.Log(context.Background(), cakelog.LevelPanic, "panic message", nil)
+
+	if fatalCounter.inc != 1 {
+		t.Errorf("Expected panic messages to fall back to the fatal counter, got %f", fatalCounter.inc)
+	}
+
+	withPanic := decorator.NewPrometheusLogger(new(mockLogger), decorator.PrometheusLoggerCounter{
+		Error: errorCounter,
+		Fatal: fatalCounter,
+		Panic: panicCounter,
+	})
+
+	withPanic.Log(context.Background(), cakelog.LevelFatal, "fatal message", nil)
+	withPanic.Log(context.Background(), cakelog.LevelPanic, "panic message", nil)
+
+	if fatalCounter.inc != 2 || panicCounter.inc != 1 || errorCounter.inc != 2 {
+		t.Errorf(
+			"Expected fatal and panic counters to be used, got %f, %f, %f",
+			errorCounter.inc,
+			fatalCounter.inc,
+			panicCounter.inc,
+		)
+	}
+}
+
+func TestPrometheusLogger_TraceCounter(t *testing.T) {
+	t.Parallel()
+
+	debugCounter := new(mockPrometheusCounter)
+	traceCounter := new(mockPrometheusCounter)
+
+	withoutTrace := decorator.NewPrometheusLogger(new(mockLogger), decorator.PrometheusLoggerCounter{
+		Debug: debugCounter,
+	})
+
+	withoutTrace.Log(context.Background(), cakelog.LevelTrace, "trace message", nil)
+
+	if debugCounter.inc != 1 {
+		t.Errorf("Expected trace messages to fall back to the debug counter, got %f", debugCounter.inc)
+	}
+
+	withTrace := decorator.NewPrometheusLogger(new(mockLogger), decorator.PrometheusLoggerCounter{
+		Trace: traceCounter,
+		Debug: debugCounter,
+	})
+
+	withTrace.Log(context.Background(), cakelog.LevelTrace, "trace message", nil)
+	withTrace.Log(context.Background(), cakelog.LevelDebug, "debug message", nil)
+
+	if traceCounter.inc != 1 || debugCounter.inc != 2 {
+		t.Errorf("Expected trace and debug counters to be used, got %f, %f", traceCounter.inc, debugCounter.inc)
+	}
+}
+
+func TestPrometheusLogger_LazyArgs(t *testing.T) {
+	t.Parallel()
+
+	log := new(mockLogger)
+	counter := new(mockPrometheusCounter)
+	logger := decorator.NewPrometheusLogger(log, decorator.PrometheusLoggerCounter{Info: counter})
+	calls := 0
+
+	logger.Info(context.Background(), "info message", cakelog.LazyField("body", func() any {
+		calls++
+
+		return "payload"
+	}))
+
+	if counter.inc != 1 {
+		t.Errorf("Expected info counter to be incremented, got %f", counter.inc)
+	}
+
+	if calls != 0 {
+		t.Errorf("Expected the lazy value not to be resolved, got %d calls", calls)
+	}
+
+	if len(log.infoIn) != 1 || len(log.infoIn[0].args) != 2 {
+		t.Fatalf("Expected the lazy value to be forwarded, got %+v", log.infoIn)
+	}
+
+	if value := cakelog.Resolve(log.infoIn[0].args[1]); value != "payload" || calls != 1 {
+		t.Errorf("Expected the forwarded value to resolve to 'payload' once, got %v after %d calls", value, calls)
+	}
+}
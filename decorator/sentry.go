@@ -2,6 +2,8 @@ package decorator
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	"github.com/getsentry/sentry-go"
 	"github.com/yuppyweb/cakelog"
@@ -10,9 +12,15 @@ import (
 // Is the default key used to store the Sentry event ID in the log arguments.
 const DefaultSentryEventIDKey = "sentryEventId"
 
+// Is returned by SentryLogger.Sync when a Sentry client fails to deliver its buffered events before the deadline.
+var ErrSentryFlushTimeout = errors.New("sentry flush timed out")
+
 // Holds separate Sentry hubs for different log levels.
 // This allows for more granular control over which logs are sent to Sentry and how they are processed.
 type SentryLoggerHub struct {
+	// Trace level hub for capturing trace messages in Sentry. If it is nil, the debug hub is used.
+	Trace *sentry.Hub
+
 	// Debug level hub for capturing debug messages in Sentry.
 	Debug *sentry.Hub
 
@@ -24,10 +32,19 @@ type SentryLoggerHub struct {
 
 	// Error level hub for capturing error messages in Sentry.
 	Error *sentry.Hub
+
+	// Fatal level hub for capturing fatal messages in Sentry. If it is nil, the error hub is used.
+	Fatal *sentry.Hub
+
+	// Panic level hub for capturing panic messages in Sentry. If it is nil, the hub of fatal messages is used.
+	Panic *sentry.Hub
 }
 
 // SentryLogger is a decorator that sends logs to Sentry and then forwards them to the underlying logger.
 type SentryLogger struct {
+	// Provides the logging methods, which pass every message to Handle as a cakelog.Record.
+	*cakelog.HandlerLogger
+
 	// The underlying logger to which logs will be forwarded after being sent to Sentry.
 	log cakelog.Logger
 
@@ -40,58 +57,165 @@ type SentryLogger struct {
 
 // Creates a new SentryLogger with the provided underlying logger and Sentry hubs.
 func NewSentryLogger(log cakelog.Logger, hub SentryLoggerHub) *SentryLogger {
-	return &SentryLogger{
-		log:              log,
-		hub:              hub,
-		SentryEventIDKey: DefaultSentryEventIDKey,
-	}
+	return newSentryLogger(log, hub, DefaultSentryEventIDKey)
 }
 
-// Sends a debug message to Sentry and then forwards it to the underlying logger.
-func (sl *SentryLogger) Debug(ctx context.Context, msg string, args ...any) {
-	sl.log.Debug(ctx, msg, sl.captureMessage(sl.hub.Debug, msg, args)...)
+// Returns a cakelog.Middleware that wraps a logger in a SentryLogger with the given hubs.
+func SentryMiddleware(hub SentryLoggerHub) cakelog.Middleware {
+	return func(log cakelog.Logger) cakelog.Logger {
+		return NewSentryLogger(log, hub)
+	}
 }
 
-// Sends an info message to Sentry and then forwards it to the underlying logger.
-func (sl *SentryLogger) Info(ctx context.Context, msg string, args ...any) {
-	sl.log.Info(ctx, msg, sl.captureMessage(sl.hub.Info, msg, args)...)
-}
+// Sends the record to the hub of the closest level and then forwards it to the underlying logger.
+// An error is captured as an exception, otherwise the message is captured.
+// A typed nil pointer error is treated like a nil error and removed from the record.
+// The ID of the captured event is added to the record under SentryEventIDKey.
+func (sl *SentryLogger) Handle(record cakelog.Record) {
+	if cakelog.IsNilError(record.Err) {
+		record.Err = nil
+	}
 
-// Sends a warning message to Sentry and then forwards it to the underlying logger.
-func (sl *SentryLogger) Warn(ctx context.Context, msg string, args ...any) {
-	sl.log.Warn(ctx, msg, sl.captureMessage(sl.hub.Warn, msg, args)...)
+	hub := sl.hub.forLevel(record.Level)
+
+	if hub != nil {
+		var eventID *sentry.EventID
+
+		if record.Err != nil {
+			eventID = hub.CaptureException(record.Err)
+		} else {
+			eventID = hub.CaptureMessage(record.Message)
+		}
+
+		if eventID != nil {
+			record.Add(sl.SentryEventIDKey, eventID)
+		}
+	}
+
+	cakelog.Handle(sl.log, record)
 }
 
-// Sends an error message to Sentry and then forwards it to the underlying logger.
-func (sl *SentryLogger) Error(ctx context.Context, err error, args ...any) {
-	sl.log.Error(ctx, err, sl.captureException(sl.hub.Error, err, args)...)
+// Reports whether a message at the given level would be sent to Sentry or written by the underlying logger.
+// A level with a hub is always enabled, so Sentry receives every message even if the logger discards it.
+func (sl *SentryLogger) Enabled(ctx context.Context, level cakelog.Level) bool {
+	return sl.hub.forLevel(level) != nil || cakelog.Enabled(ctx, sl.log, level)
 }
 
-// Helper method to capture a message in Sentry and return the updated log arguments with the Sentry event ID.
-func (sl *SentryLogger) captureMessage(hub *sentry.Hub, msg string, args []any) []any {
-	if hub == nil {
-		return args
+// Flushes the events buffered by the clients of all hubs and then the messages of the underlying logger.
+// Each client is flushed once, waiting at most until the context is done.
+func (sl *SentryLogger) Sync(ctx context.Context) error {
+	var errs []error
+
+	flushed := make(map[*sentry.Client]struct{})
+
+	hubs := []*sentry.Hub{
+		sl.hub.Trace, sl.hub.Debug, sl.hub.Info, sl.hub.Warn, sl.hub.Error, sl.hub.Fatal, sl.hub.Panic,
 	}
 
-	if eventID := hub.CaptureMessage(msg); eventID != nil {
-		return append(args, map[string]*sentry.EventID{sl.SentryEventIDKey: eventID})
+	for _, hub := range hubs {
+		if hub == nil || hub.Client() == nil {
+			continue
+		}
+
+		if _, ok := flushed[hub.Client()]; ok {
+			continue
+		}
+
+		flushed[hub.Client()] = struct{}{}
+
+		if !hub.FlushWithContext(ctx) {
+			errs = append(errs, fmt.Errorf("%w: %w", ErrSentryFlushTimeout, context.Cause(ctx)))
+		}
 	}
 
-	return args
+	return errors.Join(append(errs, cakelog.Sync(ctx, sl.log))...)
 }
 
-// Helper method to capture an exception in Sentry and return the updated log arguments with the Sentry event ID.
-func (sl *SentryLogger) captureException(hub *sentry.Hub, err error, args []any) []any {
-	if hub == nil {
-		return args
+// Releases the resources of the underlying logger.
+// The hubs and their clients are owned by the caller and are not closed.
+func (sl *SentryLogger) Close(ctx context.Context) error {
+	return cakelog.Close(ctx, sl.log)
+}
+
+// Returns the underlying logger.
+func (sl *SentryLogger) Unwrap() cakelog.Logger {
+	return sl.log
+}
+
+// Returns a SentryLogger that binds the given arguments to the underlying logger with cakelog.With.
+// The returned logger uses the same hubs and event ID key.
+func (sl *SentryLogger) With(args ...any) cakelog.Logger {
+	return newSentryLogger(cakelog.With(sl.log, args...), sl.hub, sl.SentryEventIDKey)
+}
+
+// Returns a SentryLogger that nests the arguments in a group of the underlying logger with cakelog.WithGroup.
+// The returned logger uses the same hubs and event ID key.
+func (sl *SentryLogger) WithGroup(name string) cakelog.Logger {
+	return newSentryLogger(cakelog.WithGroup(sl.log, name), sl.hub, sl.SentryEventIDKey)
+}
+
+// Helper method to pick the hub for the given level.
+// Levels between the known ones are handled by the closest lower level.
+// The trace level falls back to the debug level if it is not set,
+// and fatal and panic levels fall back to the closest lower level that is set, down to the error level.
+func (slh SentryLoggerHub) forLevel(level cakelog.Level) *sentry.Hub {
+	switch {
+	case level < cakelog.LevelDebug && slh.Trace != nil:
+		return slh.Trace
+	case level < cakelog.LevelInfo:
+		return slh.Debug
+	case level < cakelog.LevelWarn:
+		return slh.Info
+	case level < cakelog.LevelError:
+		return slh.Warn
+	case level < cakelog.LevelFatal:
+		return slh.Error
+	case level >= cakelog.LevelPanic && slh.Panic != nil:
+		return slh.Panic
+	case slh.Fatal != nil:
+		return slh.Fatal
+	default:
+		return slh.Error
 	}
+}
 
-	if eventID := hub.CaptureException(err); eventID != nil {
-		return append(args, map[string]*sentry.EventID{sl.SentryEventIDKey: eventID})
+// Helper function to create a SentryLogger whose logging methods pass records to its Handle method.
+func newSentryLogger(log cakelog.Logger, hub SentryLoggerHub, eventIDKey string) *SentryLogger {
+	sl := &SentryLogger{
+		log:              log,
+		hub:              hub,
+		SentryEventIDKey: eventIDKey,
 	}
+	sl.HandlerLogger = cakelog.NewHandlerLogger(sl)
 
-	return args
+	return sl
 }
 
-// Ensures that SentryLogger implements the cakelog.Logger interface.
-var _ cakelog.Logger = (*SentryLogger)(nil)
+var (
+	// Ensures that SentryLogger implements the cakelog.Logger interface.
+	_ cakelog.Logger = (*SentryLogger)(nil)
+
+	// Ensures that SentryLogger implements the cakelog.Handler interface.
+	_ cakelog.Handler = (*SentryLogger)(nil)
+
+	// Ensures that SentryLogger implements the cakelog.LevelLogger interface.
+	_ cakelog.LevelLogger = (*SentryLogger)(nil)
+
+	// Ensures that SentryLogger implements the cakelog.WithLogger interface.
+	_ cakelog.WithLogger = (*SentryLogger)(nil)
+
+	// Ensures that SentryLogger implements the cakelog.GroupLogger interface.
+	_ cakelog.GroupLogger = (*SentryLogger)(nil)
+
+	// Ensures that SentryLogger implements the cakelog.Enabler interface.
+	_ cakelog.Enabler = (*SentryLogger)(nil)
+
+	// Ensures that SentryLogger implements the cakelog.Syncer interface.
+	_ cakelog.Syncer = (*SentryLogger)(nil)
+
+	// Ensures that SentryLogger implements the cakelog.Closer interface.
+	_ cakelog.Closer = (*SentryLogger)(nil)
+
+	// Ensures that SentryLogger implements the cakelog.Unwrapper interface.
+	_ cakelog.Unwrapper = (*SentryLogger)(nil)
+)
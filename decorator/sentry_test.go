@@ -7,11 +7,14 @@ import (
 	"time"
 
 	"github.com/getsentry/sentry-go"
+	"github.com/yuppyweb/cakelog"
 	"github.com/yuppyweb/cakelog/decorator"
 )
 
 type mockSentryTransport struct {
-	Event *sentry.Event
+	Event      *sentry.Event
+	Flushes    int
+	FlushFails bool
 }
 
 func (m *mockSentryTransport) Flush(time.Duration) bool {
@@ -19,7 +22,9 @@ func (m *mockSentryTransport) Flush(time.Duration) bool {
 }
 
 func (m *mockSentryTransport) FlushWithContext(context.Context) bool {
-	return true
+	m.Flushes++
+
+	return !m.FlushFails
 }
 
 func (m *mockSentryTransport) Configure(sentry.ClientOptions) {}
@@ -67,8 +72,8 @@ func TestSentryLogger_Debug(t *testing.T) {
 		t.Errorf("Expected message to be '%s', got '%s'", msg, mockLogger.debugIn[0].msg)
 	}
 
-	if len(mockLogger.debugIn[0].args) != 3 {
-		t.Fatalf("Expected 3 arguments, got %d", len(mockLogger.debugIn[0].args))
+	if len(mockLogger.debugIn[0].args) != 4 {
+		t.Fatalf("Expected 4 arguments, got %d", len(mockLogger.debugIn[0].args))
 	}
 
 	if mockLogger.debugIn[0].args[0] != "debug" {
@@ -79,19 +84,18 @@ func TestSentryLogger_Debug(t *testing.T) {
 		t.Errorf("Expected second argument to be 42, got '%v'", mockLogger.debugIn[0].args[1])
 	}
 
-	sentryArgs, ok := mockLogger.debugIn[0].args[2].(map[string]*sentry.EventID)
-	if !ok {
+	if mockLogger.debugIn[0].args[2] != logger.SentryEventIDKey {
 		t.Fatalf(
-			"Expected third argument to be a map[string]*sentry.EventID, got '%T'",
-			mockLogger.debugIn[0].args[2],
+			"Expected Sentry event ID key '%s' to be present in log arguments",
+			logger.SentryEventIDKey,
 		)
 	}
 
-	eventID, ok := sentryArgs[logger.SentryEventIDKey]
+	eventID, ok := mockLogger.debugIn[0].args[3].(*sentry.EventID)
 	if !ok {
 		t.Fatalf(
-			"Expected Sentry event ID key '%s' to be present in log arguments",
-			logger.SentryEventIDKey,
+			"Expected Sentry event ID to be a *sentry.EventID, got '%T'",
+			mockLogger.debugIn[0].args[3],
 		)
 	}
 
@@ -151,8 +155,8 @@ func TestSentryLogger_Info(t *testing.T) {
 		t.Errorf("Expected message to be '%s', got '%s'", msg, mockLogger.infoIn[0].msg)
 	}
 
-	if len(mockLogger.infoIn[0].args) != 3 {
-		t.Fatalf("Expected 3 arguments, got %d", len(mockLogger.infoIn[0].args))
+	if len(mockLogger.infoIn[0].args) != 4 {
+		t.Fatalf("Expected 4 arguments, got %d", len(mockLogger.infoIn[0].args))
 	}
 
 	if mockLogger.infoIn[0].args[0] != "info" {
@@ -163,19 +167,18 @@ func TestSentryLogger_Info(t *testing.T) {
 		t.Errorf("Expected second argument to be 57, got '%v'", mockLogger.infoIn[0].args[1])
 	}
 
-	sentryArgs, ok := mockLogger.infoIn[0].args[2].(map[string]*sentry.EventID)
-	if !ok {
+	if mockLogger.infoIn[0].args[2] != logger.SentryEventIDKey {
 		t.Fatalf(
-			"Expected third argument to be a map[string]*sentry.EventID, got '%T'",
-			mockLogger.infoIn[0].args[2],
+			"Expected Sentry event ID key '%s' to be present in log arguments",
+			logger.SentryEventIDKey,
 		)
 	}
 
-	eventID, ok := sentryArgs[logger.SentryEventIDKey]
+	eventID, ok := mockLogger.infoIn[0].args[3].(*sentry.EventID)
 	if !ok {
 		t.Fatalf(
-			"Expected Sentry event ID key '%s' to be present in log arguments",
-			logger.SentryEventIDKey,
+			"Expected Sentry event ID to be a *sentry.EventID, got '%T'",
+			mockLogger.infoIn[0].args[3],
 		)
 	}
 
@@ -235,8 +238,8 @@ func TestSentryLogger_Warn(t *testing.T) {
 		t.Errorf("Expected message to be '%s', got '%s'", msg, mockLogger.warnIn[0].msg)
 	}
 
-	if len(mockLogger.warnIn[0].args) != 3 {
-		t.Fatalf("Expected 3 arguments, got %d", len(mockLogger.warnIn[0].args))
+	if len(mockLogger.warnIn[0].args) != 4 {
+		t.Fatalf("Expected 4 arguments, got %d", len(mockLogger.warnIn[0].args))
 	}
 
 	if mockLogger.warnIn[0].args[0] != "warn" {
@@ -247,19 +250,18 @@ func TestSentryLogger_Warn(t *testing.T) {
 		t.Errorf("Expected second argument to be 69, got '%v'", mockLogger.warnIn[0].args[1])
 	}
 
-	sentryArgs, ok := mockLogger.warnIn[0].args[2].(map[string]*sentry.EventID)
-	if !ok {
+	if mockLogger.warnIn[0].args[2] != logger.SentryEventIDKey {
 		t.Fatalf(
-			"Expected third argument to be a map[string]*sentry.EventID, got '%T'",
-			mockLogger.warnIn[0].args[2],
+			"Expected Sentry event ID key '%s' to be present in log arguments",
+			logger.SentryEventIDKey,
 		)
 	}
 
-	eventID, ok := sentryArgs[logger.SentryEventIDKey]
+	eventID, ok := mockLogger.warnIn[0].args[3].(*sentry.EventID)
 	if !ok {
 		t.Fatalf(
-			"Expected Sentry event ID key '%s' to be present in log arguments",
-			logger.SentryEventIDKey,
+			"Expected Sentry event ID to be a *sentry.EventID, got '%T'",
+			mockLogger.warnIn[0].args[3],
 		)
 	}
 
@@ -320,8 +322,8 @@ func TestSentryLogger_Error(t *testing.T) {
 		t.Errorf("Expected error to be '%v', got '%v'", expectedErr, mockLogger.errorIn[0].err)
 	}
 
-	if len(mockLogger.errorIn[0].args) != 3 {
-		t.Fatalf("Expected 3 arguments, got %d", len(mockLogger.errorIn[0].args))
+	if len(mockLogger.errorIn[0].args) != 4 {
+		t.Fatalf("Expected 4 arguments, got %d", len(mockLogger.errorIn[0].args))
 	}
 
 	if mockLogger.errorIn[0].args[0] != "error" {
@@ -332,19 +334,18 @@ func TestSentryLogger_Error(t *testing.T) {
 		t.Errorf("Expected second argument to be 85, got '%v'", mockLogger.errorIn[0].args[1])
 	}
 
-	sentryArgs, ok := mockLogger.errorIn[0].args[2].(map[string]*sentry.EventID)
-	if !ok {
+	if mockLogger.errorIn[0].args[2] != logger.SentryEventIDKey {
 		t.Fatalf(
-			"Expected third argument to be a map[string]*sentry.EventID, got '%T'",
-			mockLogger.errorIn[0].args[2],
+			"Expected Sentry event ID key '%s' to be present in log arguments",
+			logger.SentryEventIDKey,
 		)
 	}
 
-	eventID, ok := sentryArgs[logger.SentryEventIDKey]
+	eventID, ok := mockLogger.errorIn[0].args[3].(*sentry.EventID)
 	if !ok {
 		t.Fatalf(
-			"Expected Sentry event ID key '%s' to be present in log arguments",
-			logger.SentryEventIDKey,
+			"Expected Sentry event ID to be a *sentry.EventID, got '%T'",
+			mockLogger.errorIn[0].args[3],
 		)
 	}
 
@@ -427,23 +428,22 @@ func TestSentryLogger_LogChainWithHub(t *testing.T) {
 		)
 	}
 
-	if len(mockLogger.debugIn[0].args) != 1 {
-		t.Fatalf("Expected 1 argument, got %d", len(mockLogger.debugIn[0].args))
+	if len(mockLogger.debugIn[0].args) != 2 {
+		t.Fatalf("Expected 2 arguments, got %d", len(mockLogger.debugIn[0].args))
 	}
 
-	sentryArgs, ok := mockLogger.debugIn[0].args[0].(map[string]*sentry.EventID)
-	if !ok {
+	if mockLogger.debugIn[0].args[0] != logger.SentryEventIDKey {
 		t.Fatalf(
-			"Expected third argument to be a map[string]*sentry.EventID, got '%T'",
-			mockLogger.debugIn[0].args[0],
+			"Expected Sentry event ID key '%s' to be present in log arguments",
+			logger.SentryEventIDKey,
 		)
 	}
 
-	eventID, ok := sentryArgs[logger.SentryEventIDKey]
+	eventID, ok := mockLogger.debugIn[0].args[1].(*sentry.EventID)
 	if !ok {
 		t.Fatalf(
-			"Expected Sentry event ID key '%s' to be present in log arguments",
-			logger.SentryEventIDKey,
+			"Expected Sentry event ID to be a *sentry.EventID, got '%T'",
+			mockLogger.debugIn[0].args[1],
 		)
 	}
 
@@ -472,23 +472,22 @@ func TestSentryLogger_LogChainWithHub(t *testing.T) {
 		)
 	}
 
-	if len(mockLogger.infoIn[0].args) != 1 {
-		t.Fatalf("Expected 1 argument, got %d", len(mockLogger.infoIn[0].args))
+	if len(mockLogger.infoIn[0].args) != 2 {
+		t.Fatalf("Expected 2 arguments, got %d", len(mockLogger.infoIn[0].args))
 	}
 
-	sentryArgs, ok = mockLogger.infoIn[0].args[0].(map[string]*sentry.EventID)
-	if !ok {
+	if mockLogger.infoIn[0].args[0] != logger.SentryEventIDKey {
 		t.Fatalf(
-			"Expected third argument to be a map[string]*sentry.EventID, got '%T'",
-			mockLogger.infoIn[0].args[0],
+			"Expected Sentry event ID key '%s' to be present in log arguments",
+			logger.SentryEventIDKey,
 		)
 	}
 
-	eventID, ok = sentryArgs[logger.SentryEventIDKey]
+	eventID, ok = mockLogger.infoIn[0].args[1].(*sentry.EventID)
 	if !ok {
 		t.Fatalf(
-			"Expected Sentry event ID key '%s' to be present in log arguments",
-			logger.SentryEventIDKey,
+			"Expected Sentry event ID to be a *sentry.EventID, got '%T'",
+			mockLogger.infoIn[0].args[1],
 		)
 	}
 
@@ -517,23 +516,22 @@ func TestSentryLogger_LogChainWithHub(t *testing.T) {
 		)
 	}
 
-	if len(mockLogger.warnIn[0].args) != 1 {
-		t.Fatalf("Expected 1 argument, got %d", len(mockLogger.warnIn[0].args))
+	if len(mockLogger.warnIn[0].args) != 2 {
+		t.Fatalf("Expected 2 arguments, got %d", len(mockLogger.warnIn[0].args))
 	}
 
-	sentryArgs, ok = mockLogger.warnIn[0].args[0].(map[string]*sentry.EventID)
-	if !ok {
+	if mockLogger.warnIn[0].args[0] != logger.SentryEventIDKey {
 		t.Fatalf(
-			"Expected third argument to be a map[string]*sentry.EventID, got '%T'",
-			mockLogger.warnIn[0].args[0],
+			"Expected Sentry event ID key '%s' to be present in log arguments",
+			logger.SentryEventIDKey,
 		)
 	}
 
-	eventID, ok = sentryArgs[logger.SentryEventIDKey]
+	eventID, ok = mockLogger.warnIn[0].args[1].(*sentry.EventID)
 	if !ok {
 		t.Fatalf(
-			"Expected Sentry event ID key '%s' to be present in log arguments",
-			logger.SentryEventIDKey,
+			"Expected Sentry event ID to be a *sentry.EventID, got '%T'",
+			mockLogger.warnIn[0].args[1],
 		)
 	}
 
@@ -570,23 +568,22 @@ func TestSentryLogger_LogChainWithHub(t *testing.T) {
 		)
 	}
 
-	if len(mockLogger.errorIn[0].args) != 1 {
-		t.Fatalf("Expected 1 argument, got %d", len(mockLogger.errorIn[0].args))
+	if len(mockLogger.errorIn[0].args) != 2 {
+		t.Fatalf("Expected 2 arguments, got %d", len(mockLogger.errorIn[0].args))
 	}
 
-	sentryArgs, ok = mockLogger.errorIn[0].args[0].(map[string]*sentry.EventID)
-	if !ok {
+	if mockLogger.errorIn[0].args[0] != logger.SentryEventIDKey {
 		t.Fatalf(
-			"Expected third argument to be a map[string]*sentry.EventID, got '%T'",
-			mockLogger.errorIn[0].args[0],
+			"Expected Sentry event ID key '%s' to be present in log arguments",
+			logger.SentryEventIDKey,
 		)
 	}
 
-	eventID, ok = sentryArgs[logger.SentryEventIDKey]
+	eventID, ok = mockLogger.errorIn[0].args[1].(*sentry.EventID)
 	if !ok {
 		t.Fatalf(
-			"Expected Sentry event ID key '%s' to be present in log arguments",
-			logger.SentryEventIDKey,
+			"Expected Sentry event ID to be a *sentry.EventID, got '%T'",
+			mockLogger.errorIn[0].args[1],
 		)
 	}
 
@@ -700,3 +697,314 @@ func TestSentryLogger_WithEmptyClient(t *testing.T) {
 		t.Fatalf("Expected Error to be called once, got %d", len(mockLogger.errorIn))
 	}
 }
+
+func TestSentryLogger_LogWithMessageAndError(t *testing.T) {
+	t.Parallel()
+
+	mockLogger := new(mockLogger)
+	mockTransport := new(mockSentryTransport)
+
+	client, err := sentry.NewClient(sentry.ClientOptions{
+		Dsn:       "https://examplePublicKey@o0.ingest.sentry.io/0",
+		Transport: mockTransport,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create Sentry client: %v", err)
+	}
+
+	hub := decorator.SentryLoggerHub{
+		Warn: sentry.NewHub(client, sentry.NewScope()),
+	}
+
+	logger := decorator.NewSentryLogger(mockLogger, hub)
+	expectedErr := errors.New("retry failed")
+
+	logger.Log(context.Background(), cakelog.LevelWarn+1, "warn message", expectedErr)
+
+	if mockTransport.Event == nil {
+		t.Fatalf("Expected Sentry event to be captured, got nil")
+	}
+
+	if len(mockTransport.Event.Exception) == 0 {
+		t.Fatalf("Expected Sentry event to contain an exception, got none")
+	}
+
+	if mockTransport.Event.Exception[0].Value != expectedErr.Error() {
+		t.Errorf(
+			"Expected Sentry exception value to be '%s', got '%s'",
+			expectedErr,
+			mockTransport.Event.Exception[0].Value,
+		)
+	}
+
+	if len(mockLogger.warnIn) != 1 {
+		t.Fatalf("Expected Warn to be called once, got %d", len(mockLogger.warnIn))
+	}
+
+	if mockLogger.warnIn[0].msg != "warn message" {
+		t.Errorf("Expected Warn message to be 'warn message', got '%s'", mockLogger.warnIn[0].msg)
+	}
+
+	if len(mockLogger.warnIn[0].args) != 4 {
+		t.Fatalf("Expected 4 arguments, got %d", len(mockLogger.warnIn[0].args))
+	}
+
+	if _, ok := mockLogger.warnIn[0].args[1].(*sentry.EventID); !ok {
+		t.Errorf(
+			"Expected second argument to be a *sentry.EventID, got '%T'",
+			mockLogger.warnIn[0].args[1],
+		)
+	}
+
+	if mockLogger.warnIn[0].args[3] != expectedErr {
+		t.Errorf("Expected error argument to be '%v', got '%v'", expectedErr, mockLogger.warnIn[0].args[3])
+	}
+}
+
+type sentryPointerError struct{}
+
+func (*sentryPointerError) Error() string {
+	return "pointer error"
+}
+
+func TestSentryLogger_LogWithTypedNilError(t *testing.T) {
+	t.Parallel()
+
+	mockLogger := new(mockLogger)
+	mockTransport := new(mockSentryTransport)
+
+	client, err := sentry.NewClient(sentry.ClientOptions{
+		Dsn:       "https://examplePublicKey@o0.ingest.sentry.io/0",
+		Transport: mockTransport,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create Sentry client: %v", err)
+	}
+
+	hub := decorator.SentryLoggerHub{
+		Warn: sentry.NewHub(client, sentry.NewScope()),
+	}
+
+	logger := decorator.NewSentryLogger(mockLogger, hub)
+
+	var typedNil *sentryPointerError
+
+	logger.Log(context.Background(), cakelog.LevelWarn, "warn message", typedNil)
+
+	if mockTransport.Event == nil {
+		t.Fatalf("Expected Sentry event to be captured, got nil")
+	}
+
+	if len(mockTransport.Event.Exception) != 0 {
+		t.Errorf("Expected Sentry event to contain no exception, got %d", len(mockTransport.Event.Exception))
+	}
+
+	if mockTransport.Event.Message != "warn message" {
+		t.Errorf("Expected Sentry message to be 'warn message', got '%s'", mockTransport.Event.Message)
+	}
+
+	if len(mockLogger.warnIn) != 1 {
+		t.Fatalf("Expected Warn to be called once, got %d", len(mockLogger.warnIn))
+	}
+
+	if len(mockLogger.warnIn[0].args) != 2 {
+		t.Errorf("Expected only the event ID argument, got %v", mockLogger.warnIn[0].args)
+	}
+}
+
+func TestSentryLogger_Enabled(t *testing.T) {
+	t.Parallel()
+
+	logger := decorator.NewSentryLogger(
+		&mockEnabledLogger{minLevel: cakelog.LevelError},
+		decorator.SentryLoggerHub{Warn: sentry.NewHub(nil, nil)},
+	)
+
+	if logger.Enabled(context.Background(), cakelog.LevelInfo) {
+		t.Error("Expected info without a hub to be disabled")
+	}
+
+	if !logger.Enabled(context.Background(), cakelog.LevelWarn) {
+		t.Error("Expected warn with a hub to be enabled")
+	}
+
+	if !logger.Enabled(context.Background(), cakelog.LevelError) {
+		t.Error("Expected error enabled by the underlying logger to be enabled")
+	}
+}
+
+func TestSentryLogger_Sync(t *testing.T) {
+	t.Parallel()
+
+	mockLogger := new(mockLifecycleLogger)
+	mockTransport := new(mockSentryTransport)
+
+	client, err := sentry.NewClient(sentry.ClientOptions{
+		Dsn:       "https://examplePublicKey@o0.ingest.sentry.io/0",
+		Transport: mockTransport,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create Sentry client: %v", err)
+	}
+
+	hub := sentry.NewHub(client, sentry.NewScope())
+
+	logger := decorator.NewSentryLogger(mockLogger, decorator.SentryLoggerHub{
+		Debug: sentry.NewHub(nil, nil),
+		Warn:  hub,
+		Error: hub,
+	})
+
+	if err := cakelog.Shutdown(context.Background(), logger); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if mockTransport.Flushes != 1 {
+		t.Errorf("Expected the shared client to be flushed once, got %d", mockTransport.Flushes)
+	}
+
+	if mockLogger.syncs != 1 || mockLogger.closes != 1 {
+		t.Errorf("Expected the underlying logger to be synced and closed once, got %d and %d",
+			mockLogger.syncs, mockLogger.closes)
+	}
+}
+
+func TestSentryLogger_SyncTimeout(t *testing.T) {
+	t.Parallel()
+
+	expectedErr := errors.New("sync failed")
+	mockLogger := &mockLifecycleLogger{syncErr: expectedErr}
+	mockTransport := &mockSentryTransport{FlushFails: true}
+
+	client, err := sentry.NewClient(sentry.ClientOptions{
+		Dsn:       "https://examplePublicKey@o0.ingest.sentry.io/0",
+		Transport: mockTransport,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create Sentry client: %v", err)
+	}
+
+	logger := decorator.NewSentryLogger(mockLogger, decorator.SentryLoggerHub{
+		Error: sentry.NewHub(client, sentry.NewScope()),
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err = logger.Sync(ctx)
+
+	if !errors.Is(err, decorator.ErrSentryFlushTimeout) {
+		t.Errorf("Expected error to wrap ErrSentryFlushTimeout, got %v", err)
+	}
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected error to wrap the context error, got %v", err)
+	}
+
+	if !errors.Is(err, expectedErr) {
+		t.Errorf("Expected error to wrap the underlying logger error, got %v", err)
+	}
+}
+
+func TestSentryLogger_FatalHub(t *testing.T) {
+	t.Parallel()
+
+	errorTransport := new(mockSentryTransport)
+	fatalTransport := new(mockSentryTransport)
+
+	newHub := func(transport *mockSentryTransport) *sentry.Hub {
+		client, err := sentry.NewClient(sentry.ClientOptions{
+			Dsn:       "https://examplePublicKey@o0.ingest.sentry.io/0",
+			Transport: transport,
+		})
+		if err != nil {
+			t.Fatalf("Failed to create Sentry client: %v", err)
+		}
+
+		return sentry.NewHub(client, sentry.NewScope())
+	}
+
+	logger := decorator.NewSentryLogger(new(mockLogger), decorator.SentryLoggerHub{
+		Error: newHub(errorTransport),
+		Fatal: newHub(fatalTransport),
+	})
+
+	logger.Log(context.Background(), cakelog.LevelError, "error message", nil)
+	logger.Log(context.Background(), cakelog.LevelPanic, "panic message", nil)
+
+	if errorTransport.Event == nil || errorTransport.Event.Message != "error message" {
+		t.Errorf("Expected the error hub to capture the error message, got %+v", errorTransport.Event)
+	}
+
+	if fatalTransport.Event == nil || fatalTransport.Event.Message != "panic message" {
+		t.Errorf("Expected the fatal hub to capture the panic message, got %+v", fatalTransport.Event)
+	}
+}
+
+func TestSentryLogger_TraceHub(t *testing.T) {
+	t.Parallel()
+
+	mockTransport := new(mockSentryTransport)
+
+	client, err := sentry.NewClient(sentry.ClientOptions{
+		Dsn:       "https://examplePublicKey@o0.ingest.sentry.io/0",
+		Transport: mockTransport,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create Sentry client: %v", err)
+	}
+
+	logger := decorator.NewSentryLogger(new(mockLogger), decorator.SentryLoggerHub{
+		Trace: sentry.NewHub(client, sentry.NewScope()),
+	})
+
+	logger.Log(context.Background(), cakelog.LevelDebug, "debug message", nil)
+
+	if mockTransport.Event != nil {
+		t.Errorf("Expected debug messages not to use the trace hub, got %+v", mockTransport.Event)
+	}
+
+	logger.Log(context.Background(), cakelog.LevelTrace, "trace message", nil)
+
+	if mockTransport.Event == nil || mockTransport.Event.Message != "trace message" {
+		t.Errorf("Expected the trace hub to capture the trace message, got %+v", mockTransport.Event)
+	}
+}
+
+func TestSentryLogger_LazyArgs(t *testing.T) {
+	t.Parallel()
+
+	mockTransport := new(mockSentryTransport)
+
+	client, err := sentry.NewClient(sentry.ClientOptions{
+		Dsn:       "https://examplePublicKey@o0.ingest.sentry.io/0",
+		Transport: mockTransport,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create Sentry client: %v", err)
+	}
+
+	log := new(mockLogger)
+	logger := decorator.NewSentryLogger(log, decorator.SentryLoggerHub{
+		Warn: sentry.NewHub(client, sentry.NewScope()),
+	})
+	calls := 0
+
+	logger.Warn(context.Background(), "warn message", cakelog.LazyField("body", func() any {
+		calls++
+
+		return "payload"
+	}))
+
+	if mockTransport.Event == nil || mockTransport.Event.Message != "warn message" {
+		t.Errorf("Expected the warn message to be captured, got %+v", mockTransport.Event)
+	}
+
+	if calls != 0 {
+		t.Errorf("Expected the lazy value not to be resolved, got %d calls", calls)
+	}
+
+	if len(log.warnIn) != 1 {
+		t.Fatalf("Expected 1 warn call, got %d", len(log.warnIn))
+	}
+}
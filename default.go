@@ -0,0 +1,60 @@
+package cakelog
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// Is a holder for the default logger, since atomic.Pointer needs a concrete type.
+type defaultLogger struct {
+	// The default logger of the process.
+	logger Logger
+}
+
+// Is the context key type under which IntoContext stores a logger.
+// This is unexported to prevent collisions with other context keys.
+type loggerContextKey struct{}
+
+// Holds the default logger of the process, which is a NopLogger until SetDefault is called.
+//
+//nolint:gochecknoglobals // The default logger is process-wide by design.
+var defaultHolder atomic.Pointer[defaultLogger]
+
+// Returns the default logger of the process.
+// It is a NopLogger until another logger is set with SetDefault.
+func Default() Logger {
+	if holder := defaultHolder.Load(); holder != nil {
+		return holder.logger
+	}
+
+	return NewNopLogger()
+}
+
+// Replaces the default logger of the process. It is safe to call concurrently with Default.
+// A nil logger restores the NopLogger.
+func SetDefault(logger Logger) {
+	if logger == nil {
+		defaultHolder.Store(nil)
+
+		return
+	}
+
+	defaultHolder.Store(&defaultLogger{logger: logger})
+}
+
+// Returns a copy of the context that carries the provided logger, to be retrieved with FromContext.
+func IntoContext(ctx context.Context, logger Logger) context.Context {
+	return context.WithValue(ctx, loggerContextKey{}, logger)
+}
+
+// Returns the logger carried by the context with IntoContext,
+// or the default logger if the context carries none.
+func FromContext(ctx context.Context) Logger {
+	if ctx != nil {
+		if logger, ok := ctx.Value(loggerContextKey{}).(Logger); ok && logger != nil {
+			return logger
+		}
+	}
+
+	return Default()
+}
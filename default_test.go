@@ -0,0 +1,61 @@
+package cakelog_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/yuppyweb/cakelog"
+)
+
+//nolint:paralleltest // The test changes the process-wide default logger.
+func TestDefault(t *testing.T) {
+	t.Cleanup(func() { cakelog.SetDefault(nil) })
+
+	if _, ok := cakelog.Default().(*cakelog.NopLogger); !ok {
+		t.Fatalf("expected the default logger to be a NopLogger, got %T", cakelog.Default())
+	}
+
+	logger := new(mockLogger)
+
+	var wg sync.WaitGroup
+
+	for range 8 {
+		wg.Go(func() {
+			cakelog.SetDefault(logger)
+
+			if cakelog.Default() == nil {
+				t.Error("expected the default logger to be set")
+			}
+		})
+	}
+
+	wg.Wait()
+
+	if cakelog.Default() != logger {
+		t.Errorf("expected the default logger to be replaced, got %T", cakelog.Default())
+	}
+
+	cakelog.SetDefault(nil)
+
+	if _, ok := cakelog.Default().(*cakelog.NopLogger); !ok {
+		t.Errorf("expected a nil logger to restore the NopLogger, got %T", cakelog.Default())
+	}
+}
+
+func TestFromContext(t *testing.T) {
+	t.Parallel()
+
+	logger := new(mockLogger)
+	ctx := cakelog.IntoContext(context.Background(), cakelog.With(logger, "requestID", "r-1"))
+
+	cakelog.FromContext(ctx).Info(ctx, "info message")
+
+	if len(logger.calls) != 1 || len(logger.calls[0].args) != 1 {
+		t.Fatalf("expected the carried logger to be used, got %+v", logger.calls)
+	}
+
+	if cakelog.FromContext(context.Background()) == nil {
+		t.Error("expected a context without a logger to return the default logger")
+	}
+}
@@ -0,0 +1,147 @@
+package cakelog
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// Is the key under which adapters write the structured chain of a wrapped or joined error, next to the error itself.
+const ErrorChainKey = "errorChain"
+
+// Is the maximum number of errors in a chain returned by ErrorChain, which guards against cyclic error trees.
+const maxErrorChain = 32
+
+// Is an optional interface for errors that carry structured data, such as an HTTP status or a query,
+// which they contribute as key-value arguments to their entry in the error chain.
+type FieldsProvider interface {
+	// Returns alternating keys and values, in the form accepted by the methods of the Logger interface.
+	LogFields() []any
+}
+
+// Is a single error of an error chain.
+type ErrorDetail struct {
+	// The text of the error.
+	Message string
+
+	// The Go type of the error, such as "*fs.PathError".
+	Type string
+
+	// The normalized fields contributed by the error if it implements FieldsProvider.
+	Fields []Field
+}
+
+// Encodes the error as an object with the "message" and "type" keys, and the "fields" key if it has fields.
+func (ed ErrorDetail) MarshalJSON() ([]byte, error) {
+	object := map[string]any{
+		"message": ed.Message,
+		"type":    ed.Type,
+	}
+
+	if len(ed.Fields) > 0 {
+		object["fields"] = fieldsMap(ed.Fields)
+	}
+
+	data, err := json.Marshal(object)
+	if err != nil {
+		return nil, fmt.Errorf("marshal error detail: %w", err)
+	}
+
+	return data, nil
+}
+
+// Returns the errors of the chain of err, starting with err itself and visiting the errors it wraps depth first,
+// through both Unwrap() error and the Unwrap() []error of errors.Join, so the last entry is a root cause.
+// Nil errors, including typed nil pointers, are skipped, and the chain is cut after 32 errors.
+func ErrorChain(err error) []ErrorDetail {
+	var chain []ErrorDetail
+
+	appendErrorChain(&chain, err)
+
+	return chain
+}
+
+// Reports whether the chain of err carries more than the error itself: wrapped or joined errors, or fields.
+// Adapters write the chain only for such errors, since a plain error is already fully described by its text.
+func HasErrorChain(err error) bool {
+	if IsNilError(err) {
+		return false
+	}
+
+	if _, ok := err.(FieldsProvider); ok {
+		return true
+	}
+
+	switch wrapper := err.(type) {
+	case interface{ Unwrap() error }:
+		return !IsNilError(wrapper.Unwrap())
+	case interface{ Unwrap() []error }:
+		return len(wrapper.Unwrap()) > 0
+	default:
+		return false
+	}
+}
+
+// Helper function to append the error and the errors it wraps to the chain.
+func appendErrorChain(chain *[]ErrorDetail, err error) {
+	if IsNilError(err) || len(*chain) >= maxErrorChain {
+		return
+	}
+
+	detail := ErrorDetail{
+		Message: err.Error(),
+		Type:    fmt.Sprintf("%T", err),
+	}
+
+	if provider, ok := err.(FieldsProvider); ok {
+		detail.Fields = Normalize(provider.LogFields()...)
+	}
+
+	*chain = append(*chain, detail)
+
+	switch wrapper := err.(type) {
+	case interface{ Unwrap() error }:
+		appendErrorChain(chain, wrapper.Unwrap())
+	case interface{ Unwrap() []error }:
+		for _, child := range wrapper.Unwrap() {
+			appendErrorChain(chain, child)
+		}
+	default:
+	}
+}
+
+// Reports whether an error is nil, including a typed nil pointer whose Error method would usually panic.
+// Adapters treat such errors like a nil error.
+func IsNilError(err error) bool {
+	if err == nil {
+		return true
+	}
+
+	switch value := reflect.ValueOf(err); value.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan, reflect.Interface:
+		return value.IsNil()
+	default:
+		return false
+	}
+}
+
+// Helper function to convert fields to a map for encoding, with nested fields becoming nested maps
+// and lazy values resolved.
+func fieldsMap(fields []Field) map[string]any {
+	values := make(map[string]any, len(fields))
+
+	for _, field := range fields {
+		value := Resolve(field.Value)
+
+		if group, ok := value.([]Field); ok {
+			values[field.Key] = fieldsMap(group)
+		} else {
+			values[field.Key] = value
+		}
+	}
+
+	return values
+}
+
+// Ensures that ErrorDetail implements the json.Marshaler interface.
+var _ json.Marshaler = ErrorDetail{}
@@ -0,0 +1,149 @@
+package cakelog_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"reflect"
+	"testing"
+
+	"github.com/yuppyweb/cakelog"
+)
+
+type statusError struct {
+	status int
+	err    error
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("status %d: %v", e.status, e.err)
+}
+
+func (e *statusError) Unwrap() error {
+	return e.err
+}
+
+func (e *statusError) LogFields() []any {
+	return []any{"status", e.status}
+}
+
+var _ cakelog.FieldsProvider = (*statusError)(nil)
+
+func TestErrorChain(t *testing.T) {
+	t.Parallel()
+
+	root := errors.New("connection refused")
+	err := fmt.Errorf("fetch user: %w", &statusError{status: 503, err: root})
+
+	expected := []cakelog.ErrorDetail{
+		{Message: "fetch user: status 503: connection refused", Type: "*fmt.wrapError"},
+		{
+			Message: "status 503: connection refused",
+			Type:    "*cakelog_test.statusError",
+			Fields:  []cakelog.Field{{Key: "status", Value: 503}},
+		},
+		{Message: "connection refused", Type: "*errors.errorString"},
+	}
+
+	if chain := cakelog.ErrorChain(err); !reflect.DeepEqual(chain, expected) {
+		t.Errorf("unexpected chain:\nGot:  %+v\nWant: %+v", chain, expected)
+	}
+
+	if !cakelog.HasErrorChain(err) || cakelog.HasErrorChain(root) {
+		t.Error("expected only the wrapped error to have a chain")
+	}
+}
+
+func TestErrorChain_Joined(t *testing.T) {
+	t.Parallel()
+
+	first := &fs.PathError{Op: "open", Path: "a.txt", Err: fs.ErrNotExist}
+	second := errors.New("disk full")
+	chain := cakelog.ErrorChain(errors.Join(first, nil, second))
+
+	var messages []string
+
+	for _, detail := range chain {
+		messages = append(messages, detail.Message)
+	}
+
+	expected := []string{"open a.txt: file does not exist\ndisk full", "open a.txt: file does not exist",
+		"file does not exist", "disk full"}
+
+	if !reflect.DeepEqual(messages, expected) {
+		t.Errorf("expected the joined errors depth first, got %q", messages)
+	}
+
+	if chain[1].Type != "*fs.PathError" {
+		t.Errorf("expected the Go type of the error, got %q", chain[1].Type)
+	}
+}
+
+func TestErrorChain_Nil(t *testing.T) {
+	t.Parallel()
+
+	var typedNil *statusError
+
+	if cakelog.ErrorChain(nil) != nil || cakelog.ErrorChain(typedNil) != nil {
+		t.Error("expected no chain for nil errors")
+	}
+
+	if !cakelog.IsNilError(typedNil) || cakelog.IsNilError(errors.New("error")) {
+		t.Error("expected typed nil pointers to be nil errors")
+	}
+
+	if cakelog.HasErrorChain(typedNil) {
+		t.Error("expected no chain for a typed nil error")
+	}
+
+	if !cakelog.HasErrorChain(&statusError{status: 500, err: errors.New("internal")}) {
+		t.Error("expected a fields provider to have a chain")
+	}
+}
+
+func TestErrorChain_Limit(t *testing.T) {
+	t.Parallel()
+
+	err := errors.New("root")
+
+	for idx := range 40 {
+		err = fmt.Errorf("layer %d: %w", idx, err)
+	}
+
+	if chain := cakelog.ErrorChain(err); len(chain) != 32 {
+		t.Errorf("expected the chain to be cut after 32 errors, got %d", len(chain))
+	}
+}
+
+func TestErrorDetail_MarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	detail := cakelog.ErrorDetail{
+		Message: "status 503",
+		Type:    "*api.StatusError",
+		Fields: []cakelog.Field{
+			{Key: "status", Value: 503},
+			cakelog.NewGroup("request", "path", "/users"),
+			cakelog.LazyField("retry", func() any { return true }),
+		},
+	}
+
+	data, err := json.Marshal(detail)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	expected := `{"fields":{"request":{"path":"/users"},"retry":true,"status":503},` +
+		`"message":"status 503","type":"*api.StatusError"}`
+
+	if string(data) != expected {
+		t.Errorf("unexpected JSON:\nGot:  %s\nWant: %s", data, expected)
+	}
+
+	data, _ = json.Marshal(cakelog.ErrorDetail{Message: "plain", Type: "*errors.errorString"})
+
+	if string(data) != `{"message":"plain","type":"*errors.errorString"}` {
+		t.Errorf("expected no fields key without fields, got %s", data)
+	}
+}
@@ -0,0 +1,92 @@
+package cakelog
+
+import (
+	"context"
+	"os"
+	"sync/atomic"
+	"time"
+)
+
+// Is the time Fatal and Panic wait for the logger to be flushed, and closed by Fatal, unless the context ends sooner.
+const terminateShutdownTimeout = 5 * time.Second
+
+// Is the exit code used by Fatal.
+const fatalExitCode = 1
+
+// Is a holder for the exit function, since atomic.Pointer needs a concrete type.
+type exitHolder struct {
+	// The function called by Fatal to terminate the process.
+	exit func(code int)
+}
+
+// Holds the function called by Fatal to terminate the process, which is os.Exit unless replaced with SetExitFunc.
+//
+//nolint:gochecknoglobals // The exit function is process-wide by design.
+var exitFunc atomic.Pointer[exitHolder]
+
+// Replaces the function called by Fatal to terminate the process, so tests can observe the exit.
+// A nil function restores os.Exit.
+func SetExitFunc(exit func(code int)) {
+	if exit == nil {
+		exitFunc.Store(nil)
+
+		return
+	}
+
+	exitFunc.Store(&exitHolder{exit: exit})
+}
+
+// Writes a message at LevelFatal through the whole decorator chain of the provided logger,
+// shuts the logger down with Shutdown and exits the process with code 1.
+// The shutdown is bounded by a timeout of 5 seconds and is not cancelled together with the context.
+// Adapters write fatal and panic messages at their native level without exiting or panicking,
+// so the decorators are flushed first.
+func Fatal(ctx context.Context, logger Logger, msg string, err error, args ...any) {
+	terminate(ctx, logger, LevelFatal, msg, err, args, Shutdown)
+
+	if holder := exitFunc.Load(); holder != nil {
+		holder.exit(fatalExitCode)
+
+		return
+	}
+
+	os.Exit(fatalExitCode)
+}
+
+// Writes a message at LevelPanic through the whole decorator chain of the provided logger,
+// flushes the logger with Sync and panics with an error combining the message and the error.
+// The logger is not closed, since the panic may be recovered, as net/http does for handlers,
+// and the process then keeps logging through it. The flush is bounded like the shutdown in Fatal.
+func Panic(ctx context.Context, logger Logger, msg string, err error, args ...any) {
+	terminate(ctx, logger, LevelPanic, msg, err, args, Sync)
+
+	value := messageErr(msg, err)
+	if value == nil {
+		value = messageError(LevelPanic.String())
+	}
+
+	panic(value)
+}
+
+// Helper function to write the last message at the given level and then flush the logger with the given function,
+// Shutdown or Sync. Errors of the flush are ignored, since there is nowhere left to report them.
+func terminate(
+	ctx context.Context,
+	logger Logger,
+	level Level,
+	msg string,
+	err error,
+	args []any,
+	flush func(context.Context, Logger) error,
+) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	Log(ctx, logger, level, msg, err, args...)
+
+	flushCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), terminateShutdownTimeout)
+	defer cancel()
+
+	_ = flush(flushCtx, logger)
+}
@@ -0,0 +1,123 @@
+package cakelog_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/yuppyweb/cakelog"
+)
+
+//nolint:paralleltest // The test changes the process-wide exit function.
+func TestFatal(t *testing.T) {
+	t.Cleanup(func() { cakelog.SetExitFunc(nil) })
+
+	logger := new(mockLifecycleLogger)
+	expectedErr := errors.New("failed")
+
+	var codes []int
+
+	cakelog.SetExitFunc(func(code int) {
+		logger.calls = append(logger.calls, "exit")
+		codes = append(codes, code)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	cakelog.Fatal(ctx, logger, "cannot start", expectedErr, "port", 8080)
+
+	if !reflect.DeepEqual(logger.calls, []string{"sync", "close", "exit"}) {
+		t.Errorf("expected the logger to be shut down before exiting, got %v", logger.calls)
+	}
+
+	if !reflect.DeepEqual(codes, []int{1}) {
+		t.Errorf("expected exit code 1, got %v", codes)
+	}
+
+	if len(logger.mockLogger.calls) != 1 || logger.mockLogger.calls[0].method != "error" {
+		t.Fatalf("expected the fatal message to be written as an error, got %+v", logger.mockLogger.calls)
+	}
+
+	if err := logger.mockLogger.calls[0].err; !errors.Is(err, expectedErr) || err.Error() != "cannot start: failed" {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestFatal_NilContext(t *testing.T) {
+	t.Cleanup(func() { cakelog.SetExitFunc(nil) })
+
+	logger := new(mockLifecycleLogger)
+
+	cakelog.SetExitFunc(func(int) {
+		logger.calls = append(logger.calls, "exit")
+	})
+
+	var ctx context.Context
+
+	cakelog.Fatal(ctx, logger, "cannot start", nil)
+
+	if !reflect.DeepEqual(logger.calls, []string{"sync", "close", "exit"}) {
+		t.Errorf("expected the logger to be shut down before exiting, got %v", logger.calls)
+	}
+}
+
+func TestPanic(t *testing.T) {
+	t.Parallel()
+
+	handler := new(mockHandler)
+
+	defer func() {
+		recovered := recover()
+
+		err, ok := recovered.(error)
+		if !ok || err.Error() != "invariant broken" {
+			t.Errorf("expected to panic with the message, got %v", recovered)
+		}
+
+		if len(handler.records) != 1 || handler.records[0].Level != cakelog.LevelPanic {
+			t.Errorf("expected a panic level record, got %+v", handler.records)
+		}
+	}()
+
+	cakelog.Panic(context.Background(), cakelog.NewHandlerLogger(handler), "invariant broken", nil)
+}
+
+func TestPanic_DoesNotClose(t *testing.T) {
+	t.Parallel()
+
+	logger := new(mockLifecycleLogger)
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected to panic")
+			}
+		}()
+
+		cakelog.Panic(context.Background(), logger, "invariant broken", nil)
+	}()
+
+	if !reflect.DeepEqual(logger.calls, []string{"sync"}) {
+		t.Errorf("expected the logger to be synced but not closed, got %v", logger.calls)
+	}
+
+	logger.Info(context.Background(), "recovered")
+
+	if len(logger.mockLogger.calls) != 2 {
+		t.Errorf("expected the logger to keep logging after a recovered panic, got %+v", logger.mockLogger.calls)
+	}
+}
+
+func TestPanic_Empty(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if err, ok := recover().(error); !ok || err.Error() != "panic" {
+			t.Errorf("expected to panic with the level name, got %v", err)
+		}
+	}()
+
+	cakelog.Panic(context.Background(), cakelog.NewNopLogger(), "", nil)
+}
@@ -0,0 +1,134 @@
+package cakelog
+
+import (
+	"fmt"
+	"log/slog"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Is the key given to arguments that cannot be paired with a key, following the convention of log/slog.
+const BadKey = "!BADKEY"
+
+// Is a single key-value attribute of a log message.
+// A value of type []Field is a group of nested attributes.
+type Field struct {
+	// The name of the attribute.
+	Key string
+
+	// The value of the attribute.
+	Value any
+}
+
+// Creates a new Field with the given key and value.
+func NewField(key string, value any) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Creates a new Field that groups the given fields under a single key.
+// The arguments are normalized with Normalize.
+func NewGroup(key string, args ...any) Field {
+	return Field{Key: key, Value: Normalize(args...)}
+}
+
+// Converts the variadic arguments of a log call into an ordered list of fields.
+// The arguments are processed from left to right with the following rules:
+//   - a Field is taken as is, and a []Field is taken element by element;
+//   - a slog.Attr is converted to a Field, with slog groups becoming nested []Field values
+//     and groups with an empty key being inlined;
+//   - a map is expanded to one field per entry, sorted by key,
+//     with non-string keys formatted by fmt.Sprint;
+//   - a string is a key and the argument following it is its value;
+//   - a string without a following argument, or any other value in the position of a key,
+//     becomes the value of a field with BadKey.
+func Normalize(args ...any) []Field {
+	if len(args) == 0 {
+		return nil
+	}
+
+	fields := make([]Field, 0, len(args))
+
+	for idx := 0; idx < len(args); idx++ {
+		switch arg := args[idx].(type) {
+		case Field:
+			fields = append(fields, arg)
+		case []Field:
+			fields = append(fields, arg...)
+		case slog.Attr:
+			fields = appendAttr(fields, arg)
+		case map[string]any:
+			fields = appendStringMap(fields, arg)
+		case string:
+			if idx+1 == len(args) {
+				fields = append(fields, Field{Key: BadKey, Value: arg})
+			} else {
+				idx++
+				fields = append(fields, Field{Key: arg, Value: args[idx]})
+			}
+		default:
+			if value := reflect.ValueOf(arg); value.Kind() == reflect.Map {
+				fields = appendMap(fields, value)
+			} else {
+				fields = append(fields, Field{Key: BadKey, Value: arg})
+			}
+		}
+	}
+
+	return fields
+}
+
+// Helper function to append a slog.Attr to the fields, converting groups to nested fields.
+func appendAttr(fields []Field, attr slog.Attr) []Field {
+	if attr.Value.Kind() != slog.KindGroup {
+		return append(fields, Field{Key: attr.Key, Value: attr.Value.Any()})
+	}
+
+	group := make([]Field, 0, len(attr.Value.Group()))
+
+	for _, member := range attr.Value.Group() {
+		group = appendAttr(group, member)
+	}
+
+	if attr.Key == "" {
+		return append(fields, group...)
+	}
+
+	return append(fields, Field{Key: attr.Key, Value: group})
+}
+
+// Helper function to append the entries of a map with string keys to the fields, sorted by key.
+func appendStringMap(fields []Field, values map[string]any) []Field {
+	keys := make([]string, 0, len(values))
+
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		fields = append(fields, Field{Key: key, Value: values[key]})
+	}
+
+	return fields
+}
+
+// Helper function to append the entries of an arbitrary map to the fields, sorted by the formatted key.
+func appendMap(fields []Field, value reflect.Value) []Field {
+	entries := make([]Field, 0, value.Len())
+
+	iter := value.MapRange()
+	for iter.Next() {
+		entries = append(entries, Field{
+			Key:   fmt.Sprint(iter.Key().Interface()),
+			Value: iter.Value().Interface(),
+		})
+	}
+
+	slices.SortFunc(entries, func(a, b Field) int {
+		return strings.Compare(a.Key, b.Key)
+	})
+
+	return append(fields, entries...)
+}
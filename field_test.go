@@ -0,0 +1,125 @@
+package cakelog_test
+
+import (
+	"log/slog"
+	"reflect"
+	"testing"
+
+	"github.com/yuppyweb/cakelog"
+)
+
+func TestNormalize(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		args     []any
+		expected []cakelog.Field
+	}{
+		{
+			name:     "no arguments",
+			args:     nil,
+			expected: nil,
+		},
+		{
+			name: "key-value pairs",
+			args: []any{"user", 42, "name", "alice"},
+			expected: []cakelog.Field{
+				{Key: "user", Value: 42},
+				{Key: "name", Value: "alice"},
+			},
+		},
+		{
+			name: "odd number of arguments",
+			args: []any{"user", 42, "dangling"},
+			expected: []cakelog.Field{
+				{Key: "user", Value: 42},
+				{Key: cakelog.BadKey, Value: "dangling"},
+			},
+		},
+		{
+			name: "non-string key",
+			args: []any{42, "user", "alice"},
+			expected: []cakelog.Field{
+				{Key: cakelog.BadKey, Value: 42},
+				{Key: "user", Value: "alice"},
+			},
+		},
+		{
+			name: "fields",
+			args: []any{cakelog.NewField("user", 42), []cakelog.Field{{Key: "a", Value: 1}, {Key: "b", Value: 2}}},
+			expected: []cakelog.Field{
+				{Key: "user", Value: 42},
+				{Key: "a", Value: 1},
+				{Key: "b", Value: 2},
+			},
+		},
+		{
+			name: "string map sorted by key",
+			args: []any{map[string]any{"b": 2, "a": 1}, "c", 3},
+			expected: []cakelog.Field{
+				{Key: "a", Value: 1},
+				{Key: "b", Value: 2},
+				{Key: "c", Value: 3},
+			},
+		},
+		{
+			name: "arbitrary map with formatted keys",
+			args: []any{map[any]any{2: "two", "one": 1}},
+			expected: []cakelog.Field{
+				{Key: "2", Value: "two"},
+				{Key: "one", Value: 1},
+			},
+		},
+		{
+			name: "map as a value",
+			args: []any{"meta", map[string]any{"a": 1}},
+			expected: []cakelog.Field{
+				{Key: "meta", Value: map[string]any{"a": 1}},
+			},
+		},
+		{
+			name: "slog attributes and groups",
+			args: []any{
+				slog.Int("user", 42),
+				slog.Group("req", slog.String("method", "GET"), slog.Group("", slog.Int("status", 200))),
+				slog.Group("", slog.Bool("inline", true)),
+			},
+			expected: []cakelog.Field{
+				{Key: "user", Value: int64(42)},
+				{Key: "req", Value: []cakelog.Field{
+					{Key: "method", Value: "GET"},
+					{Key: "status", Value: int64(200)},
+				}},
+				{Key: "inline", Value: true},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := cakelog.Normalize(test.args...)
+
+			if !reflect.DeepEqual(got, test.expected) {
+				t.Errorf("unexpected fields:\nGot:  %#v\nWant: %#v", got, test.expected)
+			}
+		})
+	}
+}
+
+func TestNewGroup(t *testing.T) {
+	t.Parallel()
+
+	group := cakelog.NewGroup("req", "method", "GET", map[string]any{"status": 200})
+
+	expected := cakelog.Field{Key: "req", Value: []cakelog.Field{
+		{Key: "method", Value: "GET"},
+		{Key: "status", Value: 200},
+	}}
+
+	if !reflect.DeepEqual(group, expected) {
+		t.Errorf("unexpected group:\nGot:  %#v\nWant: %#v", group, expected)
+	}
+}
@@ -0,0 +1,187 @@
+package cakelog
+
+import (
+	"context"
+	"runtime"
+)
+
+// Is the interface for components that process log messages as a single Record,
+// instead of implementing every method of the Logger interface.
+// A handler may also implement Enabler, Syncer and Closer, which are used by the HandlerLogger wrapping it.
+type Handler interface {
+	// Processes the record. The handler may add attributes to the record before passing it on.
+	Handle(record Record)
+}
+
+// Writes the record to the provided logger.
+// If the logger implements Handler, the record is passed to it as is,
+// otherwise it is replayed with the Log function, with the attributes as alternating keys and values.
+// Replaying loses the time and the program counter of the record.
+func Handle(logger Logger, record Record) {
+	if handler, ok := logger.(Handler); ok {
+		handler.Handle(record)
+
+		return
+	}
+
+	Log(record.Context, logger, record.Level, record.Message, record.Err, record.Args()...)
+}
+
+// HandlerLogger is a Logger that builds a Record for every message and passes it to a Handler.
+// Decorators can embed it, passing themselves as the handler, to get all logging methods from a single Handle method.
+// Such decorators must define their own Enabled, Sync, Close and Unwrap methods,
+// since the promoted ones would ask the decorator itself.
+type HandlerLogger struct {
+	// The handler to which the records are passed.
+	handler Handler
+}
+
+// Creates a new HandlerLogger that passes records to the provided handler.
+func NewHandlerLogger(handler Handler) *HandlerLogger {
+	return &HandlerLogger{handler: handler}
+}
+
+// Passes a debug record to the handler.
+func (hl *HandlerLogger) Debug(ctx context.Context, msg string, args ...any) {
+	hl.log(ctx, LevelDebug, msg, nil, args)
+}
+
+// Passes an info record to the handler.
+func (hl *HandlerLogger) Info(ctx context.Context, msg string, args ...any) {
+	hl.log(ctx, LevelInfo, msg, nil, args)
+}
+
+// Passes a warning record to the handler.
+func (hl *HandlerLogger) Warn(ctx context.Context, msg string, args ...any) {
+	hl.log(ctx, LevelWarn, msg, nil, args)
+}
+
+// Passes an error record to the handler.
+func (hl *HandlerLogger) Error(ctx context.Context, err error, args ...any) {
+	hl.log(ctx, LevelError, "", err, args)
+}
+
+// Passes a record at the given level to the handler.
+func (hl *HandlerLogger) Log(ctx context.Context, level Level, msg string, err error, args ...any) {
+	hl.log(ctx, level, msg, err, args)
+}
+
+// Passes an existing record to the handler, keeping its time and program counter.
+func (hl *HandlerLogger) Handle(record Record) {
+	hl.handler.Handle(record)
+}
+
+// Reports whether the handler would process a record at the given level.
+// Handlers that do not implement Enabler are assumed to process records at every level.
+func (hl *HandlerLogger) Enabled(ctx context.Context, level Level) bool {
+	if enabler, ok := hl.handler.(Enabler); ok {
+		return enabler.Enabled(ctx, level)
+	}
+
+	return true
+}
+
+// Flushes the handler if it implements Syncer.
+func (hl *HandlerLogger) Sync(ctx context.Context) error {
+	if syncer, ok := hl.handler.(Syncer); ok {
+		return syncer.Sync(ctx)
+	}
+
+	return nil
+}
+
+// Closes the handler if it implements Closer.
+func (hl *HandlerLogger) Close(ctx context.Context) error {
+	if closer, ok := hl.handler.(Closer); ok {
+		return closer.Close(ctx)
+	}
+
+	return nil
+}
+
+// Returns the logger wrapped by the handler if it implements Unwrapper, otherwise nil.
+func (hl *HandlerLogger) Unwrap() Logger {
+	if unwrapper, ok := hl.handler.(Unwrapper); ok {
+		return unwrapper.Unwrap()
+	}
+
+	return nil
+}
+
+// Helper method to build a record with the program counter of the caller of the logging method.
+func (hl *HandlerLogger) log(ctx context.Context, level Level, msg string, err error, args []any) {
+	record := NewRecord(ctx, level, msg, err, args...)
+
+	var pcs [1]uintptr
+
+	// Skips runtime.Callers, this method and the logging method.
+	runtime.Callers(3, pcs[:])
+	record.PC = pcs[0]
+
+	hl.handler.Handle(record)
+}
+
+// LoggerHandler is a Handler that writes records to a Logger with the Handle function.
+type LoggerHandler struct {
+	// The logger to which the records are written.
+	logger Logger
+}
+
+// Creates a new LoggerHandler that writes records to the provided logger.
+func NewLoggerHandler(logger Logger) *LoggerHandler {
+	return &LoggerHandler{logger: logger}
+}
+
+// Writes the record to the logger.
+func (lh *LoggerHandler) Handle(record Record) {
+	Handle(lh.logger, record)
+}
+
+// Reports whether the logger would write a message at the given level.
+func (lh *LoggerHandler) Enabled(ctx context.Context, level Level) bool {
+	return Enabled(ctx, lh.logger, level)
+}
+
+// Flushes the buffered messages of the logger.
+func (lh *LoggerHandler) Sync(ctx context.Context) error {
+	return Sync(ctx, lh.logger)
+}
+
+// Releases the resources of the logger.
+func (lh *LoggerHandler) Close(ctx context.Context) error {
+	return Close(ctx, lh.logger)
+}
+
+// Returns the logger to which the records are written.
+func (lh *LoggerHandler) Unwrap() Logger {
+	return lh.logger
+}
+
+var (
+	// Ensures that HandlerLogger implements the Logger interface.
+	_ Logger = (*HandlerLogger)(nil)
+
+	// Ensures that HandlerLogger implements the LevelLogger interface.
+	_ LevelLogger = (*HandlerLogger)(nil)
+
+	// Ensures that HandlerLogger implements the Handler interface.
+	_ Handler = (*HandlerLogger)(nil)
+
+	// Ensures that HandlerLogger implements the Enabler interface.
+	_ Enabler = (*HandlerLogger)(nil)
+
+	// Ensures that HandlerLogger implements the Syncer interface.
+	_ Syncer = (*HandlerLogger)(nil)
+
+	// Ensures that HandlerLogger implements the Closer interface.
+	_ Closer = (*HandlerLogger)(nil)
+
+	// Ensures that HandlerLogger implements the Unwrapper interface.
+	_ Unwrapper = (*HandlerLogger)(nil)
+
+	// Ensures that LoggerHandler implements the Handler interface.
+	_ Handler = (*LoggerHandler)(nil)
+
+	// Ensures that LoggerHandler implements the Enabler interface.
+	_ Enabler = (*LoggerHandler)(nil)
+)
@@ -0,0 +1,98 @@
+package cakelog_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/yuppyweb/cakelog"
+)
+
+type mockHandler struct {
+	records []cakelog.Record
+}
+
+func (mh *mockHandler) Handle(record cakelog.Record) {
+	mh.records = append(mh.records, record)
+}
+
+func (mh *mockHandler) Enabled(_ context.Context, level cakelog.Level) bool {
+	return level >= cakelog.LevelWarn
+}
+
+var (
+	_ cakelog.Handler = (*mockHandler)(nil)
+	_ cakelog.Enabler = (*mockHandler)(nil)
+)
+
+func TestHandlerLogger(t *testing.T) {
+	t.Parallel()
+
+	handler := new(mockHandler)
+	logger := cakelog.NewHandlerLogger(handler)
+	expectedErr := errors.New("failed")
+
+	logger.Debug(context.Background(), "debug message", "key", "value")
+	logger.Info(context.Background(), "info message")
+	logger.Warn(context.Background(), "warn message")
+	logger.Error(context.Background(), expectedErr)
+	logger.Log(context.Background(), cakelog.LevelInfo+1, "log message", expectedErr)
+
+	if len(handler.records) != 5 {
+		t.Fatalf("expected 5 records, got %d", len(handler.records))
+	}
+
+	levels := []cakelog.Level{
+		cakelog.LevelDebug, cakelog.LevelInfo, cakelog.LevelWarn, cakelog.LevelError, cakelog.LevelInfo + 1,
+	}
+
+	for idx, record := range handler.records {
+		if record.Level != levels[idx] {
+			t.Errorf("expected record %d to have level %v, got %v", idx, levels[idx], record.Level)
+		}
+
+		frame, _ := runtime.CallersFrames([]uintptr{record.PC}).Next()
+
+		if !strings.HasSuffix(frame.Function, "TestHandlerLogger") {
+			t.Errorf("expected record %d to point at the caller, got %q", idx, frame.Function)
+		}
+	}
+
+	if !reflect.DeepEqual(handler.records[0].Args(), []any{"key", "value"}) {
+		t.Errorf("unexpected debug arguments: %v", handler.records[0].Args())
+	}
+
+	if handler.records[3].Message != "" || handler.records[3].Err != expectedErr {
+		t.Errorf("unexpected error record: %+v", handler.records[3])
+	}
+
+	if logger.Enabled(context.Background(), cakelog.LevelInfo) ||
+		!logger.Enabled(context.Background(), cakelog.LevelError) {
+		t.Error("expected Enabled to be forwarded to the handler")
+	}
+}
+
+func TestHandle(t *testing.T) {
+	t.Parallel()
+
+	record := cakelog.NewRecord(context.Background(), cakelog.LevelWarn, "warn message", nil, "key", "value")
+
+	handler := new(mockHandler)
+	cakelog.Handle(cakelog.NewHandlerLogger(handler), record)
+
+	if len(handler.records) != 1 || !reflect.DeepEqual(handler.records[0], record) {
+		t.Errorf("expected the record to be passed as is, got %+v", handler.records)
+	}
+
+	logger := new(mockLogger)
+	cakelog.NewLoggerHandler(logger).Handle(record)
+
+	expected := []mockCall{{method: "warn", msg: "warn message", args: []any{"key", "value"}}}
+
+	if !reflect.DeepEqual(logger.calls, expected) {
+		t.Errorf("expected the record to be replayed, got %+v", logger.calls)
+	}
+}
@@ -0,0 +1,123 @@
+package cakelog
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sync"
+)
+
+// LazyValue is an argument value computed by a function only when a log entry with it is written.
+// The function runs at most once, so a record passed to several loggers resolves it a single time.
+// Adapters resolve lazy values after the level check, and decorators pass them on without resolving them.
+// Lazy values bound to a logger with With are renewed with RenewLazy for every record, so they never go stale.
+type LazyValue struct {
+	// Guards the single call of the function.
+	once sync.Once
+
+	// The function that computes the value. It is kept after the call, so RenewLazy can compute the value again.
+	fn func() any
+
+	// The computed value.
+	value any
+}
+
+// Creates a new LazyValue computed by the provided function.
+func Lazy(fn func() any) *LazyValue {
+	return &LazyValue{fn: fn}
+}
+
+// Creates a new Field whose value is computed by the provided function when the entry is written.
+func LazyField(key string, fn func() any) Field {
+	return Field{Key: key, Value: Lazy(fn)}
+}
+
+// Returns the computed value, calling the function on the first call.
+// A nil function computes a nil value.
+func (lv *LazyValue) Value() any {
+	lv.once.Do(func() {
+		if lv.fn != nil {
+			lv.value = lv.fn()
+		}
+	})
+
+	return lv.value
+}
+
+// Implements slog.LogValuer, so slog handlers resolve the value only when they write it.
+func (lv *LazyValue) LogValue() slog.Value {
+	return slog.AnyValue(lv.Value())
+}
+
+// Implements json.Marshaler by encoding the computed value.
+func (lv *LazyValue) MarshalJSON() ([]byte, error) {
+	return json.Marshal(lv.Value()) //nolint:wrapcheck // The error is the encoding error of the value.
+}
+
+// Implements fmt.Stringer by formatting the computed value.
+func (lv *LazyValue) String() string {
+	return fmt.Sprint(lv.Value())
+}
+
+// Returns the computed value of a LazyValue, or the value itself otherwise.
+// Adapters call it before converting a value for their backend.
+func Resolve(value any) any {
+	if lv, ok := value.(*LazyValue); ok {
+		return lv.Value()
+	}
+
+	return value
+}
+
+// Reports whether any of the fields holds a LazyValue, also in groups.
+// Loggers use it to keep the fields bound with With that must be computed for every record apart from the others.
+func HasLazy(fields []Field) bool {
+	for _, field := range fields {
+		switch value := field.Value.(type) {
+		case *LazyValue:
+			return true
+		case []Field:
+			if HasLazy(value) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Returns a copy of the fields in which every LazyValue, also in groups, is replaced by a new unresolved LazyValue
+// with the same function, or the fields themselves if they hold no lazy value.
+// Loggers call it with the fields bound with With for every record they write, so a bound value is computed
+// at most once per record, and only if the record is written, instead of once for the lifetime of the logger.
+func RenewLazy(fields []Field) []Field {
+	if !HasLazy(fields) {
+		return fields
+	}
+
+	renewed := make([]Field, len(fields))
+
+	for idx, field := range fields {
+		switch value := field.Value.(type) {
+		case *LazyValue:
+			field.Value = Lazy(value.fn)
+		case []Field:
+			field.Value = RenewLazy(value)
+		}
+
+		renewed[idx] = field
+	}
+
+	return renewed
+}
+
+var (
+	// Ensures that LazyValue implements the slog.LogValuer interface.
+	_ slog.LogValuer = (*LazyValue)(nil)
+
+	// Ensures that LazyValue implements the json.Marshaler interface.
+	_ json.Marshaler = (*LazyValue)(nil)
+
+	// Ensures that LazyValue implements the fmt.Stringer interface.
+	_ fmt.Stringer = (*LazyValue)(nil)
+)
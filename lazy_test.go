@@ -0,0 +1,130 @@
+package cakelog_test
+
+import (
+	"encoding/json"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/yuppyweb/cakelog"
+)
+
+func TestLazy(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	lazy := cakelog.Lazy(func() any {
+		calls.Add(1)
+
+		return 42
+	})
+
+	if calls.Load() != 0 {
+		t.Fatal("expected the function not to be called before the value is needed")
+	}
+
+	var wg sync.WaitGroup
+
+	for range 10 {
+		wg.Go(func() {
+			if value := lazy.Value(); value != 42 {
+				t.Errorf("expected value 42, got %v", value)
+			}
+		})
+	}
+
+	wg.Wait()
+
+	if calls.Load() != 1 {
+		t.Errorf("expected the function to be called once, got %d", calls.Load())
+	}
+}
+
+func TestLazy_Encodings(t *testing.T) {
+	t.Parallel()
+
+	lazy := cakelog.Lazy(func() any {
+		return map[string]int{"size": 3}
+	})
+
+	if value := lazy.LogValue(); value.Kind() != slog.KindAny || value.String() != "map[size:3]" {
+		t.Errorf("unexpected slog value: %v", value)
+	}
+
+	data, err := json.Marshal(lazy)
+	if err != nil || string(data) != `{"size":3}` {
+		t.Errorf("unexpected JSON: %s %v", data, err)
+	}
+
+	if lazy.String() != "map[size:3]" {
+		t.Errorf("unexpected string: %q", lazy.String())
+	}
+}
+
+func TestLazy_NilFunction(t *testing.T) {
+	t.Parallel()
+
+	if value := cakelog.Lazy(nil).Value(); value != nil {
+		t.Errorf("expected nil value, got %v", value)
+	}
+}
+
+func TestLazyField(t *testing.T) {
+	t.Parallel()
+
+	field := cakelog.LazyField("body", func() any {
+		return "payload"
+	})
+
+	if field.Key != "body" {
+		t.Errorf("expected key 'body', got %q", field.Key)
+	}
+
+	if value := cakelog.Resolve(field.Value); value != "payload" {
+		t.Errorf("expected resolved value 'payload', got %v", value)
+	}
+
+	if value := cakelog.Resolve("plain"); value != "plain" {
+		t.Errorf("expected plain value to be returned unchanged, got %v", value)
+	}
+}
+
+func TestRenewLazy(t *testing.T) {
+	t.Parallel()
+
+	calls := 0
+	lazy := cakelog.Lazy(func() any {
+		calls++
+
+		return calls
+	})
+	fields := []cakelog.Field{
+		cakelog.NewField("user", 42),
+		cakelog.NewGroup("req", "seq", lazy),
+	}
+
+	if plain := fields[:1]; cakelog.HasLazy(plain) || &cakelog.RenewLazy(plain)[0] != &plain[0] {
+		t.Error("expected fields without lazy values to be returned unchanged")
+	}
+
+	if !cakelog.HasLazy(fields) {
+		t.Fatal("expected a lazy value in a group to be found")
+	}
+
+	lazy.Value()
+
+	for expected := 2; expected <= 3; expected++ {
+		renewed := cakelog.RenewLazy(fields)
+
+		group, ok := renewed[1].Value.([]cakelog.Field)
+		if !ok || cakelog.Resolve(group[0].Value) != expected || cakelog.Resolve(group[0].Value) != expected {
+			t.Errorf("expected a renewed value %d computed once, got %v", expected, renewed[1].Value)
+		}
+	}
+
+	if lazy.Value() != 1 {
+		t.Errorf("expected the original value to be kept, got %v", lazy.Value())
+	}
+}
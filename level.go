@@ -0,0 +1,129 @@
+package cakelog
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Is the severity of a log message.
+// Levels are ordered: a greater value means a more severe message.
+// The numeric values match the levels of log/slog, so a Level can be converted to slog.Level directly.
+type Level int
+
+const (
+	// Is the level for very verbose diagnostic messages, such as wire-level dumps.
+	LevelTrace Level = -8
+
+	// Is the level for diagnostic messages useful during development.
+	LevelDebug Level = -4
+
+	// Is the level for informational messages about the normal operation.
+	LevelInfo Level = 0
+
+	// Is the level for messages about unexpected but recoverable situations.
+	LevelWarn Level = 4
+
+	// Is the level for messages about failed operations.
+	LevelError Level = 8
+
+	// Is the level for messages after which the process exits, see Fatal.
+	LevelFatal Level = 12
+
+	// Is the level for messages after which the goroutine panics, see Panic.
+	LevelPanic Level = 16
+)
+
+// Is returned when a text cannot be parsed as a Level.
+var ErrUnknownLevel = errors.New("unknown log level")
+
+// Parses a level from its textual representation.
+// Names are case-insensitive, "warning" is accepted as an alias of "warn",
+// and an offset may follow the name, e.g. "debug-1" or "error+2".
+func ParseLevel(text string) (Level, error) {
+	name := strings.ToLower(strings.TrimSpace(text))
+	offset := 0
+
+	if idx := strings.IndexAny(name, "+-"); idx > 0 {
+		num, err := strconv.Atoi(name[idx:])
+		if err != nil {
+			return 0, fmt.Errorf("%w: %q", ErrUnknownLevel, text)
+		}
+
+		name, offset = name[:idx], num
+	}
+
+	var level Level
+
+	switch name {
+	case "trace":
+		level = LevelTrace
+	case "debug":
+		level = LevelDebug
+	case "info":
+		level = LevelInfo
+	case "warn", "warning":
+		level = LevelWarn
+	case "error":
+		level = LevelError
+	case "fatal":
+		level = LevelFatal
+	case "panic":
+		level = LevelPanic
+	default:
+		return 0, fmt.Errorf("%w: %q", ErrUnknownLevel, text)
+	}
+
+	return level + Level(offset), nil
+}
+
+// Returns the lowercase name of the level. Levels between the known ones are written as a negative offset
+// from the nearest known level above, e.g. "debug-1" or "info-2", and levels above LevelPanic as a positive offset
+// from it, e.g. "panic+1", so ParseLevel reads every text back as the same level.
+func (l Level) String() string {
+	name, base := "panic", LevelPanic
+
+	switch {
+	case l <= LevelTrace:
+		name, base = "trace", LevelTrace
+	case l <= LevelDebug:
+		name, base = "debug", LevelDebug
+	case l <= LevelInfo:
+		name, base = "info", LevelInfo
+	case l <= LevelWarn:
+		name, base = "warn", LevelWarn
+	case l <= LevelError:
+		name, base = "error", LevelError
+	case l <= LevelFatal:
+		name, base = "fatal", LevelFatal
+	}
+
+	if l == base {
+		return name
+	}
+
+	return fmt.Sprintf("%s%+d", name, int(l-base))
+}
+
+// Implements encoding.TextMarshaler, so the level is written by its name in JSON, YAML and other encodings.
+func (l Level) MarshalText() ([]byte, error) {
+	return []byte(l.String()), nil
+}
+
+// Implements encoding.TextUnmarshaler using ParseLevel.
+func (l *Level) UnmarshalText(text []byte) error {
+	level, err := ParseLevel(string(text))
+	if err != nil {
+		return err
+	}
+
+	*l = level
+
+	return nil
+}
+
+// Implements Leveler by returning the level itself, so a fixed level can be used wherever a Leveler is expected.
+func (l Level) Level() Level {
+	return l
+}
@@ -0,0 +1,147 @@
+package cakelog_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/yuppyweb/cakelog"
+)
+
+func TestLevel_String(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		level    cakelog.Level
+		expected string
+	}{
+		{cakelog.LevelDebug, "debug"},
+		{cakelog.LevelInfo, "info"},
+		{cakelog.LevelWarn, "warn"},
+		{cakelog.LevelError, "error"},
+		{cakelog.LevelTrace, "trace"},
+		{cakelog.LevelDebug - 1, "debug-1"},
+		{cakelog.LevelTrace - 2, "trace-2"},
+		{cakelog.LevelInfo + 2, "warn-2"},
+		{cakelog.LevelError + 3, "fatal-1"},
+		{cakelog.LevelFatal, "fatal"},
+		{cakelog.LevelPanic + 1, "panic+1"},
+	}
+
+	for _, test := range tests {
+		if got := test.level.String(); got != test.expected {
+			t.Errorf("expected level %d to be %q, got %q", int(test.level), test.expected, got)
+		}
+	}
+}
+
+func TestLevel_StringRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	for level := cakelog.LevelTrace - 3; level <= cakelog.LevelPanic+3; level++ {
+		parsed, err := cakelog.ParseLevel(level.String())
+		if err != nil {
+			t.Errorf("expected %q to be parsed, got %v", level.String(), err)
+
+			continue
+		}
+
+		if parsed != level {
+			t.Errorf("expected %q to be parsed as %d, got %d", level.String(), int(level), int(parsed))
+		}
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		text     string
+		expected cakelog.Level
+	}{
+		{"debug", cakelog.LevelDebug},
+		{"INFO", cakelog.LevelInfo},
+		{" Warn ", cakelog.LevelWarn},
+		{"warning", cakelog.LevelWarn},
+		{"error", cakelog.LevelError},
+		{"debug-4", cakelog.LevelDebug - 4},
+		{"Trace", cakelog.LevelTrace},
+		{"info+2", cakelog.LevelInfo + 2},
+		{"FATAL", cakelog.LevelFatal},
+		{"panic", cakelog.LevelPanic},
+	}
+
+	for _, test := range tests {
+		level, err := cakelog.ParseLevel(test.text)
+		if err != nil {
+			t.Errorf("unexpected error parsing %q: %v", test.text, err)
+
+			continue
+		}
+
+		if level != test.expected {
+			t.Errorf("expected %q to be parsed as %s, got %s", test.text, test.expected, level)
+		}
+	}
+}
+
+func TestParseLevel_Unknown(t *testing.T) {
+	t.Parallel()
+
+	for _, text := range []string{"", "verbose", "info+", "+2", "warn+x"} {
+		if _, err := cakelog.ParseLevel(text); !errors.Is(err, cakelog.ErrUnknownLevel) {
+			t.Errorf("expected ErrUnknownLevel for %q, got %v", text, err)
+		}
+	}
+}
+
+func TestLevel_Ordering(t *testing.T) {
+	t.Parallel()
+
+	levels := []cakelog.Level{
+		cakelog.LevelTrace,
+		cakelog.LevelDebug,
+		cakelog.LevelInfo,
+		cakelog.LevelWarn,
+		cakelog.LevelError,
+		cakelog.LevelFatal,
+		cakelog.LevelPanic,
+	}
+
+	for idx := 1; idx < len(levels); idx++ {
+		if levels[idx-1] >= levels[idx] {
+			t.Errorf("expected %s to be less than %s", levels[idx-1], levels[idx])
+		}
+	}
+}
+
+func TestLevel_JSON(t *testing.T) {
+	t.Parallel()
+
+	type config struct {
+		Level cakelog.Level `json:"level"`
+	}
+
+	data, err := json.Marshal(config{Level: cakelog.LevelWarn + 1})
+	if err != nil {
+		t.Fatalf("failed to marshal level: %v", err)
+	}
+
+	if string(data) != `{"level":"error-3"}` {
+		t.Errorf("unexpected JSON: %s", data)
+	}
+
+	var decoded config
+
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("failed to unmarshal level: %v", err)
+	}
+
+	if decoded.Level != cakelog.LevelWarn+1 {
+		t.Errorf("expected level %s, got %s", cakelog.LevelWarn+1, decoded.Level)
+	}
+
+	if err := json.Unmarshal([]byte(`{"level":"loud"}`), &decoded); !errors.Is(err, cakelog.ErrUnknownLevel) {
+		t.Errorf("expected ErrUnknownLevel, got %v", err)
+	}
+}
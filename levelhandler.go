@@ -0,0 +1,123 @@
+package cakelog
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Is the largest request body accepted by LevelHandler.
+const maxLevelRequestSize = 1 << 12
+
+var (
+	// Is returned to clients of LevelHandler whose request does not name a level.
+	errMissingLevel = errors.New("missing level")
+
+	// Is returned to clients of LevelHandler whose request has a duration that is not positive.
+	errInvalidDuration = errors.New("invalid duration")
+)
+
+// LevelHandler is an http.Handler that serves the level of a LevelVar as JSON, to turn on verbose logging
+// without a redeploy. GET returns the current level, and PUT changes it:
+//
+//	GET  -> {"level":"info"}
+//	PUT  {"level":"debug","duration":"15m"}
+//	     -> {"level":"debug","revertTo":"info","revertAt":"2026-01-02T15:04:05Z"}
+//
+// The duration is optional and is parsed with time.ParseDuration. With a duration the level is changed with
+// LevelVar.SetFor and reverts automatically, otherwise it is changed with LevelVar.Set.
+// The handler does no authentication, so it should only be mounted on an internal or protected endpoint.
+type LevelHandler struct {
+	// The level served by the handler.
+	level *LevelVar
+}
+
+// Is the JSON body of a PUT request of LevelHandler.
+type levelRequest struct {
+	// The new level.
+	Level *Level `json:"level"`
+
+	// How long the new level stays in effect, or empty for good.
+	Duration string `json:"duration"`
+}
+
+// Is the JSON body of the responses of LevelHandler.
+type levelResponse struct {
+	// The current level.
+	Level Level `json:"level"`
+
+	// The level restored by a pending revert.
+	RevertTo *Level `json:"revertTo,omitempty"`
+
+	// The time of a pending revert.
+	RevertAt *time.Time `json:"revertAt,omitempty"`
+}
+
+// Creates a new LevelHandler that serves the provided LevelVar.
+func NewLevelHandler(level *LevelVar) *LevelHandler {
+	return &LevelHandler{level: level}
+}
+
+// Serves GET and PUT requests for the level. Invalid requests are answered with 400 Bad Request,
+// and other methods with 405 Method Not Allowed.
+func (lh *LevelHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		if err := lh.update(w, r); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+	default:
+		w.Header().Set("Allow", "GET, PUT")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	response := levelResponse{Level: lh.level.Level()}
+
+	if revertTo, revertAt, ok := lh.level.PendingRevert(); ok {
+		response.RevertTo, response.RevertAt = &revertTo, &revertAt
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// Helper method to change the level as requested by the body of a PUT request.
+func (lh *LevelHandler) update(w http.ResponseWriter, r *http.Request) error {
+	var request levelRequest
+
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxLevelRequestSize))
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&request); err != nil {
+		return fmt.Errorf("invalid request: %w", err)
+	}
+
+	if request.Level == nil {
+		return errMissingLevel
+	}
+
+	if request.Duration == "" {
+		lh.level.Set(*request.Level)
+
+		return nil
+	}
+
+	duration, err := time.ParseDuration(request.Duration)
+	if err != nil || duration <= 0 {
+		return fmt.Errorf("%w %q: must be a positive duration such as \"15m\"", errInvalidDuration, request.Duration)
+	}
+
+	lh.level.SetFor(*request.Level, duration)
+
+	return nil
+}
+
+// Ensures that LevelHandler implements the http.Handler interface.
+var _ http.Handler = (*LevelHandler)(nil)
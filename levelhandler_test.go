@@ -0,0 +1,112 @@
+package cakelog_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yuppyweb/cakelog"
+)
+
+func serveLevel(t *testing.T, handler http.Handler, method, body string) (*httptest.ResponseRecorder, map[string]any) {
+	t.Helper()
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(method, "/log/level", strings.NewReader(body)))
+
+	var response map[string]any
+
+	if recorder.Code == http.StatusOK {
+		if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+			t.Fatalf("failed to decode response %q: %v", recorder.Body.String(), err)
+		}
+	}
+
+	return recorder, response
+}
+
+func TestLevelHandler(t *testing.T) {
+	t.Parallel()
+
+	levelVar := cakelog.NewLevelVar(cakelog.LevelInfo)
+	handler := cakelog.NewLevelHandler(levelVar)
+
+	recorder, response := serveLevel(t, handler, http.MethodGet, "")
+
+	if recorder.Code != http.StatusOK || response["level"] != "info" || len(response) != 1 {
+		t.Errorf("unexpected GET response: %d %v", recorder.Code, response)
+	}
+
+	if recorder.Header().Get("Content-Type") != "application/json" {
+		t.Errorf("unexpected content type: %q", recorder.Header().Get("Content-Type"))
+	}
+
+	_, response = serveLevel(t, handler, http.MethodPut, `{"level":"warn"}`)
+
+	if response["level"] != "warn" || levelVar.Level() != cakelog.LevelWarn {
+		t.Errorf("expected the level to be warn, got %v", response)
+	}
+}
+
+func TestLevelHandler_Duration(t *testing.T) {
+	t.Parallel()
+
+	levelVar := cakelog.NewLevelVar(cakelog.LevelInfo)
+	handler := cakelog.NewLevelHandler(levelVar)
+
+	_, response := serveLevel(t, handler, http.MethodPut, `{"level":"debug","duration":"50ms"}`)
+
+	if response["level"] != "debug" || response["revertTo"] != "info" {
+		t.Errorf("unexpected PUT response: %v", response)
+	}
+
+	revertAtText, _ := response["revertAt"].(string)
+
+	revertAt, err := time.Parse(time.RFC3339Nano, revertAtText)
+	if err != nil || revertAt.Before(time.Now().Add(-time.Second)) {
+		t.Errorf("unexpected revert time: %v %v", response["revertAt"], err)
+	}
+
+	waitForLevel(t, levelVar, cakelog.LevelInfo)
+
+	if _, response = serveLevel(t, handler, http.MethodGet, ""); len(response) != 1 {
+		t.Errorf("expected no pending revert after reverting, got %v", response)
+	}
+}
+
+func TestLevelHandler_Errors(t *testing.T) {
+	t.Parallel()
+
+	levelVar := cakelog.NewLevelVar(cakelog.LevelInfo)
+	handler := cakelog.NewLevelHandler(levelVar)
+
+	tests := map[string]string{
+		`{"level":"loud"}`:                     "unknown log level",
+		`{}`:                                   "missing level",
+		`{"level":"debug","duration":"soon"}`:  "invalid duration",
+		`{"level":"debug","duration":"-1m"}`:   "invalid duration",
+		`{"level":"debug","until":"tomorrow"}`: "unknown field",
+		`level=debug`:                          "invalid request",
+	}
+
+	for body, expected := range tests {
+		recorder, _ := serveLevel(t, handler, http.MethodPut, body)
+
+		if recorder.Code != http.StatusBadRequest || !strings.Contains(recorder.Body.String(), expected) {
+			t.Errorf("expected 400 with %q for %s, got %d %q", expected, body, recorder.Code, recorder.Body.String())
+		}
+	}
+
+	if levelVar.Level() != cakelog.LevelInfo {
+		t.Errorf("expected invalid requests to keep the level, got %v", levelVar.Level())
+	}
+
+	recorder, _ := serveLevel(t, handler, http.MethodPost, `{"level":"debug"}`)
+
+	if recorder.Code != http.StatusMethodNotAllowed || recorder.Header().Get("Allow") != "GET, PUT" {
+		t.Errorf("unexpected response to POST: %d %v", recorder.Code, recorder.Header())
+	}
+}
@@ -0,0 +1,188 @@
+package cakelog
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Is returned when a text cannot be parsed as a list of level rules.
+var ErrInvalidLevelRule = errors.New("invalid level rule")
+
+// Is the level of the loggers whose name matches a pattern.
+type LevelRule struct {
+	// The pattern matched against dotted logger names. A pattern with the glob characters of path.Match,
+	// such as "db.*", matches the names it globs. A plain name such as "http" matches that name
+	// and all names below it, such as "http.server".
+	Pattern string
+
+	// The minimum level of the matching loggers.
+	Level Level
+}
+
+// Parses a comma-separated list of rules in the form "pattern=level", such as "db.*=debug,http=warn".
+// The levels are parsed with ParseLevel, and blank entries are ignored.
+func ParseLevelRules(text string) ([]LevelRule, error) {
+	var rules []LevelRule
+
+	for entry := range strings.SplitSeq(text, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		pattern, name, ok := strings.Cut(entry, "=")
+		pattern = strings.TrimSpace(pattern)
+
+		if !ok || pattern == "" {
+			return nil, fmt.Errorf("%w: %q is not in the form pattern=level", ErrInvalidLevelRule, entry)
+		}
+
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("%w: %q: %w", ErrInvalidLevelRule, entry, err)
+		}
+
+		level, err := ParseLevel(name)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q: %w", ErrInvalidLevelRule, entry, err)
+		}
+
+		rules = append(rules, LevelRule{Pattern: pattern, Level: level})
+	}
+
+	return rules, nil
+}
+
+// Reports whether the rule applies to the logger with the given name.
+func (r LevelRule) Matches(name string) bool {
+	if !strings.ContainsAny(r.Pattern, `*?[\`) {
+		return name == r.Pattern || strings.HasPrefix(name, r.Pattern+".")
+	}
+
+	matched, _ := path.Match(r.Pattern, name)
+
+	return matched
+}
+
+// Returns the rule in the form accepted by ParseLevelRules.
+func (r LevelRule) String() string {
+	return r.Pattern + "=" + r.Level.String()
+}
+
+// LevelRegistry decides the minimum level of named loggers from a list of rules.
+// When several rules match a name, the one with the longest pattern wins, and the later one among equally long ones.
+// Names that no rule matches, including the empty name of unnamed loggers, get the level of the fallback.
+// The rules can be replaced while the registry is used, and the rule of every name is cached until then.
+type LevelRegistry struct {
+	// Provides the level of names that no rule matches.
+	fallback Leveler
+
+	// Guards the rules and the cache.
+	mu sync.RWMutex
+
+	// The rules in the order they were given.
+	rules []LevelRule
+
+	// The index of the rule that applies to every name looked up since the rules were set, or -1 if none does.
+	cache map[string]int
+}
+
+// Creates a new LevelRegistry with the given rules.
+// A nil fallback is treated as LevelInfo; a LevelVar allows changing the level of unmatched names at runtime.
+func NewLevelRegistry(fallback Leveler, rules ...LevelRule) *LevelRegistry {
+	if fallback == nil {
+		fallback = LevelInfo
+	}
+
+	lr := &LevelRegistry{fallback: fallback}
+	lr.SetRules(rules...)
+
+	return lr
+}
+
+// Replaces the rules of the registry.
+func (lr *LevelRegistry) SetRules(rules ...LevelRule) {
+	lr.mu.Lock()
+	defer lr.mu.Unlock()
+
+	lr.rules = slices.Clone(rules)
+	lr.cache = make(map[string]int)
+}
+
+// Returns a copy of the rules of the registry.
+func (lr *LevelRegistry) Rules() []LevelRule {
+	lr.mu.RLock()
+	defer lr.mu.RUnlock()
+
+	return slices.Clone(lr.rules)
+}
+
+// Returns the minimum level of the logger with the given name.
+func (lr *LevelRegistry) Level(name string) Level {
+	if level, ok := lr.cached(name); ok {
+		return level
+	}
+
+	lr.mu.Lock()
+	defer lr.mu.Unlock()
+
+	idx := lr.match(name)
+	lr.cache[name] = idx
+
+	return lr.level(idx)
+}
+
+// Reports whether the logger with the given name emits messages at the given level.
+func (lr *LevelRegistry) Enabled(name string, level Level) bool {
+	return level >= lr.Level(name)
+}
+
+// Returns the rules in the form accepted by ParseLevelRules.
+func (lr *LevelRegistry) String() string {
+	rules := lr.Rules()
+	texts := make([]string, 0, len(rules))
+
+	for _, rule := range rules {
+		texts = append(texts, rule.String())
+	}
+
+	return strings.Join(texts, ",")
+}
+
+// Helper method to return the level of a name whose rule is cached.
+func (lr *LevelRegistry) cached(name string) (Level, bool) {
+	lr.mu.RLock()
+	defer lr.mu.RUnlock()
+
+	idx, ok := lr.cache[name]
+	if !ok {
+		return 0, false
+	}
+
+	return lr.level(idx), true
+}
+
+// Helper method to find the index of the rule that applies to the name, or -1 if none does.
+func (lr *LevelRegistry) match(name string) int {
+	found := -1
+
+	for idx, rule := range lr.rules {
+		if rule.Matches(name) && (found < 0 || len(rule.Pattern) >= len(lr.rules[found].Pattern)) {
+			found = idx
+		}
+	}
+
+	return found
+}
+
+// Helper method to return the level of the rule with the given index, or of the fallback for -1.
+func (lr *LevelRegistry) level(idx int) Level {
+	if idx < 0 {
+		return lr.fallback.Level()
+	}
+
+	return lr.rules[idx].Level
+}
@@ -0,0 +1,124 @@
+package cakelog_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/yuppyweb/cakelog"
+)
+
+func TestParseLevelRules(t *testing.T) {
+	t.Parallel()
+
+	rules, err := cakelog.ParseLevelRules(" db.*=debug, http=warn,,*=error+1 ")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	expected := []cakelog.LevelRule{
+		{Pattern: "db.*", Level: cakelog.LevelDebug},
+		{Pattern: "http", Level: cakelog.LevelWarn},
+		{Pattern: "*", Level: cakelog.LevelError + 1},
+	}
+
+	if !reflect.DeepEqual(rules, expected) {
+		t.Errorf("expected %v, got %v", expected, rules)
+	}
+
+	for _, text := range []string{"db", "=debug", "db=verbose", "db[=debug"} {
+		if _, err := cakelog.ParseLevelRules(text); !errors.Is(err, cakelog.ErrInvalidLevelRule) {
+			t.Errorf("expected %q to be invalid, got %v", text, err)
+		}
+	}
+
+	if rules, err := cakelog.ParseLevelRules(""); err != nil || len(rules) != 0 {
+		t.Errorf("expected no rules for an empty text, got %v %v", rules, err)
+	}
+}
+
+func TestLevelRule_Matches(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		pattern string
+		name    string
+		matches bool
+	}{
+		{"http", "http", true},
+		{"http", "http.server", true},
+		{"http", "https", false},
+		{"db.*", "db.pool", true},
+		{"db.*", "db", false},
+		{"db.?ool", "db.pool", true},
+		{"*", "", true},
+	}
+
+	for _, test := range tests {
+		rule := cakelog.LevelRule{Pattern: test.pattern, Level: cakelog.LevelDebug}
+
+		if rule.Matches(test.name) != test.matches {
+			t.Errorf("expected %q matching %q to be %v", test.pattern, test.name, test.matches)
+		}
+	}
+}
+
+func TestLevelRegistry(t *testing.T) {
+	t.Parallel()
+
+	rules, err := cakelog.ParseLevelRules("db=warn,db.*=debug,http=warn,db.pool=error")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	fallback := cakelog.NewLevelVar(cakelog.LevelInfo)
+	registry := cakelog.NewLevelRegistry(fallback, rules...)
+
+	tests := map[string]cakelog.Level{
+		"db":          cakelog.LevelWarn,
+		"db.pool":     cakelog.LevelError,
+		"db.query":    cakelog.LevelDebug,
+		"http.server": cakelog.LevelWarn,
+		"cache":       cakelog.LevelInfo,
+		"":            cakelog.LevelInfo,
+	}
+
+	for name, expected := range tests {
+		if level := registry.Level(name); level != expected {
+			t.Errorf("expected %v for %q, got %v", expected, name, level)
+		}
+	}
+
+	fallback.Set(cakelog.LevelError)
+
+	if registry.Enabled("cache", cakelog.LevelWarn) || !registry.Enabled("db.query", cakelog.LevelDebug) {
+		t.Error("expected the fallback to apply to unmatched names only")
+	}
+
+	if registry.String() != "db=warn,db.*=debug,http=warn,db.pool=error" {
+		t.Errorf("unexpected rules: %s", registry.String())
+	}
+
+	registry.SetRules(cakelog.LevelRule{Pattern: "db", Level: cakelog.LevelTrace})
+
+	if registry.Level("db.pool") != cakelog.LevelTrace || len(registry.Rules()) != 1 {
+		t.Errorf("expected the new rules to replace the cached ones, got %v", registry.Level("db.pool"))
+	}
+
+	if cakelog.NewLevelRegistry(nil).Level("db") != cakelog.LevelInfo {
+		t.Error("expected info without a fallback")
+	}
+}
+
+func TestLevelRegistry_Ties(t *testing.T) {
+	t.Parallel()
+
+	registry := cakelog.NewLevelRegistry(cakelog.LevelInfo,
+		cakelog.LevelRule{Pattern: "db.*", Level: cakelog.LevelDebug},
+		cakelog.LevelRule{Pattern: "*.db", Level: cakelog.LevelWarn},
+	)
+
+	if level := registry.Level("db.db"); level != cakelog.LevelWarn {
+		t.Errorf("expected the later of equally long patterns to win, got %v", level)
+	}
+}
@@ -0,0 +1,154 @@
+package cakelog
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Is the interface for values that provide a minimum level, such as a fixed Level or a LevelVar
+// that can be changed at runtime.
+type Leveler interface {
+	// Returns the current minimum level.
+	Level() Level
+}
+
+// LevelVar is a Leveler whose level can be changed while it is used, for example during an incident.
+// The zero value is LevelInfo. Reading the level is a single atomic load, so it can be checked for every message,
+// and a LevelVar can be shared by several adapters and decorators.
+type LevelVar struct {
+	// The current level.
+	level atomic.Int64
+
+	// Guards the pending revert.
+	mu sync.Mutex
+
+	// The timer of the pending revert, or nil if there is none.
+	timer *time.Timer
+
+	// The level restored by the pending revert.
+	revertTo Level
+
+	// The time of the pending revert.
+	revertAt time.Time
+
+	// Identifies the latest revert, so the timer of a replaced revert does nothing.
+	generation uint64
+}
+
+// Creates a new LevelVar with the given level.
+func NewLevelVar(level Level) *LevelVar {
+	lv := new(LevelVar)
+	lv.level.Store(int64(level))
+
+	return lv
+}
+
+// Returns the current level.
+func (lv *LevelVar) Level() Level {
+	return Level(lv.level.Load())
+}
+
+// Changes the level and cancels a pending revert.
+func (lv *LevelVar) Set(level Level) {
+	lv.mu.Lock()
+	defer lv.mu.Unlock()
+
+	lv.cancelRevert()
+	lv.level.Store(int64(level))
+}
+
+// Changes the level for the given duration, after which the level in effect before is restored.
+// Calling it again while a revert is pending extends or replaces the temporary level,
+// but still restores the level in effect before the first call. A non-positive duration behaves like Set.
+func (lv *LevelVar) SetFor(level Level, duration time.Duration) {
+	if duration <= 0 {
+		lv.Set(level)
+
+		return
+	}
+
+	lv.mu.Lock()
+	defer lv.mu.Unlock()
+
+	revertTo := lv.Level()
+	if lv.timer != nil {
+		revertTo = lv.revertTo
+	}
+
+	lv.cancelRevert()
+	lv.level.Store(int64(level))
+
+	lv.generation++
+	generation := lv.generation
+
+	lv.revertTo, lv.revertAt = revertTo, time.Now().Add(duration)
+	lv.timer = time.AfterFunc(duration, func() {
+		lv.revert(generation)
+	})
+}
+
+// Returns the level that will be restored by a pending revert and its time.
+// The last result is false if no revert is pending.
+func (lv *LevelVar) PendingRevert() (Level, time.Time, bool) {
+	lv.mu.Lock()
+	defer lv.mu.Unlock()
+
+	if lv.timer == nil {
+		return 0, time.Time{}, false
+	}
+
+	return lv.revertTo, lv.revertAt, true
+}
+
+// Returns the name of the current level.
+func (lv *LevelVar) String() string {
+	return "LevelVar(" + lv.Level().String() + ")"
+}
+
+// Implements encoding.TextMarshaler by writing the name of the current level.
+func (lv *LevelVar) MarshalText() ([]byte, error) {
+	return lv.Level().MarshalText()
+}
+
+// Implements encoding.TextUnmarshaler by setting the level parsed with ParseLevel.
+func (lv *LevelVar) UnmarshalText(text []byte) error {
+	level, err := ParseLevel(string(text))
+	if err != nil {
+		return err
+	}
+
+	lv.Set(level)
+
+	return nil
+}
+
+// Helper method to restore the level when the timer of a revert fires.
+// A revert that was cancelled or replaced in the meantime does nothing.
+func (lv *LevelVar) revert(generation uint64) {
+	lv.mu.Lock()
+	defer lv.mu.Unlock()
+
+	if lv.timer == nil || lv.generation != generation {
+		return
+	}
+
+	lv.level.Store(int64(lv.revertTo))
+	lv.timer = nil
+}
+
+// Helper method to cancel the pending revert while the lock is held.
+func (lv *LevelVar) cancelRevert() {
+	if lv.timer != nil {
+		lv.timer.Stop()
+		lv.timer = nil
+	}
+}
+
+var (
+	// Ensures that Level implements the Leveler interface.
+	_ Leveler = LevelInfo
+
+	// Ensures that LevelVar implements the Leveler interface.
+	_ Leveler = (*LevelVar)(nil)
+)
@@ -0,0 +1,142 @@
+package cakelog_test
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/yuppyweb/cakelog"
+)
+
+func waitForLevel(t *testing.T, leveler cakelog.Leveler, expected cakelog.Level) {
+	t.Helper()
+
+	deadline := time.Now().Add(time.Second)
+
+	for leveler.Level() != expected {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected level %v, got %v", expected, leveler.Level())
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestLevelVar(t *testing.T) {
+	t.Parallel()
+
+	var levelVar cakelog.LevelVar
+
+	if levelVar.Level() != cakelog.LevelInfo {
+		t.Errorf("expected the zero value to be info, got %v", levelVar.Level())
+	}
+
+	levelVar.Set(cakelog.LevelDebug)
+
+	if levelVar.Level() != cakelog.LevelDebug || levelVar.String() != "LevelVar(debug)" {
+		t.Errorf("expected debug, got %v", levelVar.String())
+	}
+
+	if cakelog.NewLevelVar(cakelog.LevelWarn).Level() != cakelog.LevelWarn {
+		t.Error("expected NewLevelVar to set the level")
+	}
+
+	if cakelog.LevelError.Level() != cakelog.LevelError {
+		t.Error("expected a Level to be its own Leveler")
+	}
+}
+
+func TestLevelVar_SetFor(t *testing.T) {
+	t.Parallel()
+
+	levelVar := cakelog.NewLevelVar(cakelog.LevelWarn)
+
+	levelVar.SetFor(cakelog.LevelDebug, 20*time.Millisecond)
+
+	if levelVar.Level() != cakelog.LevelDebug {
+		t.Fatalf("expected debug, got %v", levelVar.Level())
+	}
+
+	revertTo, revertAt, ok := levelVar.PendingRevert()
+	if !ok || revertTo != cakelog.LevelWarn || revertAt.IsZero() {
+		t.Errorf("expected a pending revert to warn, got %v %v %v", revertTo, revertAt, ok)
+	}
+
+	levelVar.SetFor(cakelog.LevelTrace, 20*time.Millisecond)
+
+	if revertTo, _, _ := levelVar.PendingRevert(); revertTo != cakelog.LevelWarn {
+		t.Errorf("expected a repeated call to keep the original level, got %v", revertTo)
+	}
+
+	waitForLevel(t, levelVar, cakelog.LevelWarn)
+
+	if _, _, ok := levelVar.PendingRevert(); ok {
+		t.Error("expected no pending revert after reverting")
+	}
+}
+
+func TestLevelVar_SetCancelsRevert(t *testing.T) {
+	t.Parallel()
+
+	levelVar := cakelog.NewLevelVar(cakelog.LevelWarn)
+
+	levelVar.SetFor(cakelog.LevelDebug, 10*time.Millisecond)
+	levelVar.Set(cakelog.LevelError)
+
+	if _, _, ok := levelVar.PendingRevert(); ok {
+		t.Error("expected Set to cancel the pending revert")
+	}
+
+	time.Sleep(30 * time.Millisecond)
+
+	if levelVar.Level() != cakelog.LevelError {
+		t.Errorf("expected the level set to stay, got %v", levelVar.Level())
+	}
+
+	levelVar.SetFor(cakelog.LevelDebug, 0)
+
+	if _, _, ok := levelVar.PendingRevert(); ok || levelVar.Level() != cakelog.LevelDebug {
+		t.Error("expected a non-positive duration to set the level for good")
+	}
+}
+
+func TestLevelVar_Text(t *testing.T) {
+	t.Parallel()
+
+	levelVar := cakelog.NewLevelVar(cakelog.LevelWarn)
+
+	data, err := json.Marshal(levelVar)
+	if err != nil || string(data) != `"warn"` {
+		t.Errorf("unexpected JSON: %s %v", data, err)
+	}
+
+	if err := json.Unmarshal([]byte(`"trace"`), levelVar); err != nil || levelVar.Level() != cakelog.LevelTrace {
+		t.Errorf("expected trace, got %v %v", levelVar.Level(), err)
+	}
+
+	if err := levelVar.UnmarshalText([]byte("loud")); !errors.Is(err, cakelog.ErrUnknownLevel) {
+		t.Errorf("expected ErrUnknownLevel, got %v", err)
+	}
+}
+
+func TestLevelVar_Concurrent(t *testing.T) {
+	t.Parallel()
+
+	levelVar := cakelog.NewLevelVar(cakelog.LevelInfo)
+
+	var wg sync.WaitGroup
+
+	for idx := range 10 {
+		wg.Go(func() {
+			levelVar.SetFor(cakelog.LevelDebug, time.Millisecond*time.Duration(idx+1))
+			levelVar.Level()
+			levelVar.PendingRevert()
+		})
+	}
+
+	wg.Wait()
+
+	waitForLevel(t, levelVar, cakelog.LevelInfo)
+}
@@ -0,0 +1,45 @@
+package cakelog
+
+import (
+	"context"
+	"errors"
+)
+
+// Is an optional interface for loggers that buffer messages and can flush them to their destination.
+// Decorators implement it by flushing their own buffers and then syncing the logger they wrap.
+type Syncer interface {
+	// Flushes buffered messages, giving up when the context is done.
+	Sync(ctx context.Context) error
+}
+
+// Is an optional interface for loggers that hold resources which must be released on shutdown.
+// Decorators implement it by releasing their own resources and then closing the logger they wrap.
+type Closer interface {
+	// Releases the resources held by the logger, giving up when the context is done.
+	Close(ctx context.Context) error
+}
+
+// Flushes the buffered messages of the provided logger if it implements Syncer.
+func Sync(ctx context.Context, logger Logger) error {
+	if syncer, ok := logger.(Syncer); ok {
+		return syncer.Sync(ctx)
+	}
+
+	return nil
+}
+
+// Releases the resources of the provided logger if it implements Closer.
+func Close(ctx context.Context, logger Logger) error {
+	if closer, ok := logger.(Closer); ok {
+		return closer.Close(ctx)
+	}
+
+	return nil
+}
+
+// Flushes and then closes the provided logger, propagating through all stacked decorators.
+// Both steps are always performed and their errors are joined.
+// The deadline of the context bounds the time spent waiting for buffered messages.
+func Shutdown(ctx context.Context, logger Logger) error {
+	return errors.Join(Sync(ctx, logger), Close(ctx, logger))
+}
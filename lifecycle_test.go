@@ -0,0 +1,76 @@
+package cakelog_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/yuppyweb/cakelog"
+)
+
+type mockLifecycleLogger struct {
+	mockLogger
+
+	calls    []string
+	syncErr  error
+	closeErr error
+}
+
+func (ml *mockLifecycleLogger) Sync(context.Context) error {
+	ml.calls = append(ml.calls, "sync")
+
+	return ml.syncErr
+}
+
+func (ml *mockLifecycleLogger) Close(context.Context) error {
+	ml.calls = append(ml.calls, "close")
+
+	return ml.closeErr
+}
+
+var (
+	_ cakelog.Syncer = (*mockLifecycleLogger)(nil)
+	_ cakelog.Closer = (*mockLifecycleLogger)(nil)
+)
+
+func TestShutdown(t *testing.T) {
+	t.Parallel()
+
+	syncErr := errors.New("sync failed")
+	closeErr := errors.New("close failed")
+	logger := &mockLifecycleLogger{syncErr: syncErr, closeErr: closeErr}
+
+	err := cakelog.Shutdown(context.Background(), logger)
+
+	if !errors.Is(err, syncErr) || !errors.Is(err, closeErr) {
+		t.Errorf("expected both errors to be joined, got %v", err)
+	}
+
+	if len(logger.calls) != 2 || logger.calls[0] != "sync" || logger.calls[1] != "close" {
+		t.Errorf("expected sync to be followed by close, got %v", logger.calls)
+	}
+}
+
+func TestShutdown_Unsupported(t *testing.T) {
+	t.Parallel()
+
+	if err := cakelog.Shutdown(context.Background(), new(mockLogger)); err != nil {
+		t.Errorf("expected no error for a logger without a lifecycle, got %v", err)
+	}
+}
+
+func TestShutdown_DerivedLoggers(t *testing.T) {
+	t.Parallel()
+
+	logger := new(mockLifecycleLogger)
+
+	derived := cakelog.WithGroup(cakelog.With(logger, "service", "api"), "http")
+
+	if err := cakelog.Shutdown(context.Background(), derived); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(logger.calls) != 2 {
+		t.Errorf("expected the derived loggers to forward sync and close, got %v", logger.calls)
+	}
+}
@@ -2,8 +2,13 @@ package cakelog
 
 import (
 	"context"
+	"fmt"
 )
 
+// Is the key under which an error is added to the arguments
+// when a logger without a native error slot receives one.
+const ErrorKey = "error"
+
 type Logger interface {
 	Debug(ctx context.Context, msg string, args ...any)
 	Info(ctx context.Context, msg string, args ...any)
@@ -11,6 +16,93 @@ type Logger interface {
 	Error(ctx context.Context, err error, args ...any)
 }
 
+// Is an optional interface for loggers that can write a message at an arbitrary level.
+// Loggers that do not implement it are still usable with the Log function.
+type LevelLogger interface {
+	// Writes a message with an optional error at the given level.
+	Log(ctx context.Context, level Level, msg string, err error, args ...any)
+}
+
+// Is an optional interface for loggers that can report in advance whether a message would be written,
+// so callers can skip building expensive arguments.
+type Enabler interface {
+	// Reports whether a message at the given level would be written.
+	Enabled(ctx context.Context, level Level) bool
+}
+
+// Is an error built from a plain message, used when an error level message has no error attached.
+type messageError string
+
+func (e messageError) Error() string {
+	return string(e)
+}
+
+// Writes a message at the given level to the provided logger.
+// If the logger implements LevelLogger, the call is forwarded to its Log method.
+// Otherwise the level is mapped to the closest method of the Logger interface:
+// levels below LevelInfo go to Debug, below LevelWarn to Info, below LevelError to Warn, and the rest to Error.
+// For the Error method, the message is wrapped around the error or becomes the error if there is none;
+// for the other methods, the error is added to the arguments under ErrorKey.
+// A typed nil pointer error is treated like a nil error.
+func Log(ctx context.Context, logger Logger, level Level, msg string, err error, args ...any) {
+	if IsNilError(err) {
+		err = nil
+	}
+
+	if ll, ok := logger.(LevelLogger); ok {
+		ll.Log(ctx, level, msg, err, args...)
+
+		return
+	}
+
+	if level >= LevelError {
+		logger.Error(ctx, messageErr(msg, err), args...)
+
+		return
+	}
+
+	if err != nil {
+		args = append(args, ErrorKey, err)
+	}
+
+	switch {
+	case level < LevelInfo:
+		logger.Debug(ctx, msg, args...)
+	case level < LevelWarn:
+		logger.Info(ctx, msg, args...)
+	default:
+		logger.Warn(ctx, msg, args...)
+	}
+}
+
+// Reports whether the provided logger would write a message at the given level.
+// Loggers that do not implement Enabler are assumed to write messages at every level.
+func Enabled(ctx context.Context, logger Logger, level Level) bool {
+	if enabler, ok := logger.(Enabler); ok {
+		return enabler.Enabled(ctx, level)
+	}
+
+	return true
+}
+
+// Helper function to combine a message and an error into a single error for the Error method.
+func messageErr(msg string, err error) error {
+	if IsNilError(err) {
+		err = nil
+	}
+
+	switch {
+	case msg == "":
+		return err
+	case err == nil:
+		return messageError(msg)
+	case msg == err.Error():
+		return err
+	default:
+		return fmt.Errorf("%s: %w", msg, err)
+	}
+}
+
 type NopLogger struct{}
 
 func NewNopLogger() *NopLogger {
@@ -25,4 +117,14 @@ func (*NopLogger) Warn(context.Context, string, ...any) {}
 
 func (*NopLogger) Error(context.Context, error, ...any) {}
 
-var _ Logger = (*NopLogger)(nil)
+func (*NopLogger) Log(context.Context, Level, string, error, ...any) {}
+
+func (*NopLogger) Enabled(context.Context, Level) bool {
+	return false
+}
+
+var (
+	_ Logger      = (*NopLogger)(nil)
+	_ LevelLogger = (*NopLogger)(nil)
+	_ Enabler     = (*NopLogger)(nil)
+)
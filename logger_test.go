@@ -8,6 +8,48 @@ import (
 	"github.com/yuppyweb/cakelog"
 )
 
+type mockCall struct {
+	method string
+	msg    string
+	err    error
+	args   []any
+}
+
+type mockLogger struct {
+	calls []mockCall
+}
+
+func (ml *mockLogger) Debug(_ context.Context, msg string, args ...any) {
+	ml.calls = append(ml.calls, mockCall{method: "debug", msg: msg, args: args})
+}
+
+func (ml *mockLogger) Info(_ context.Context, msg string, args ...any) {
+	ml.calls = append(ml.calls, mockCall{method: "info", msg: msg, args: args})
+}
+
+func (ml *mockLogger) Warn(_ context.Context, msg string, args ...any) {
+	ml.calls = append(ml.calls, mockCall{method: "warn", msg: msg, args: args})
+}
+
+func (ml *mockLogger) Error(_ context.Context, err error, args ...any) {
+	ml.calls = append(ml.calls, mockCall{method: "error", err: err, args: args})
+}
+
+var _ cakelog.Logger = (*mockLogger)(nil)
+
+type mockLevelLogger struct {
+	mockLogger
+
+	levels []cakelog.Level
+}
+
+func (ml *mockLevelLogger) Log(_ context.Context, level cakelog.Level, msg string, err error, args ...any) {
+	ml.levels = append(ml.levels, level)
+	ml.calls = append(ml.calls, mockCall{method: "log", msg: msg, err: err, args: args})
+}
+
+var _ cakelog.LevelLogger = (*mockLevelLogger)(nil)
+
 func TestNopLogger(t *testing.T) {
 	t.Parallel()
 
@@ -17,4 +59,205 @@ func TestNopLogger(t *testing.T) {
 	logger.Info(context.Background(), "info message", "info", 75)
 	logger.Warn(context.Background(), "warn message", "warn", 88)
 	logger.Error(context.Background(), errors.New("error message"), "error", 90)
+	logger.Log(context.Background(), cakelog.LevelInfo, "log message", nil, "log", 93)
+}
+
+func TestLog_LevelLogger(t *testing.T) {
+	t.Parallel()
+
+	logger := new(mockLevelLogger)
+	err := errors.New("log error")
+
+	cakelog.Log(context.Background(), logger, cakelog.LevelWarn+1, "log message", err, "log", 42)
+
+	if len(logger.calls) != 1 {
+		t.Fatalf("expected 1 call, got %d", len(logger.calls))
+	}
+
+	call := logger.calls[0]
+
+	if call.method != "log" {
+		t.Errorf("expected Log to be called, got %s", call.method)
+	}
+
+	if logger.levels[0] != cakelog.LevelWarn+1 {
+		t.Errorf("expected level %s, got %s", cakelog.LevelWarn+1, logger.levels[0])
+	}
+
+	if call.msg != "log message" || !errors.Is(call.err, err) || len(call.args) != 2 {
+		t.Errorf("unexpected call: %+v", call)
+	}
+}
+
+func TestLog_FallbackLevels(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		level    cakelog.Level
+		expected string
+	}{
+		{cakelog.LevelDebug - 4, "debug"},
+		{cakelog.LevelDebug, "debug"},
+		{cakelog.LevelInfo - 1, "debug"},
+		{cakelog.LevelInfo, "info"},
+		{cakelog.LevelWarn - 1, "info"},
+		{cakelog.LevelWarn, "warn"},
+		{cakelog.LevelError - 1, "warn"},
+		{cakelog.LevelError, "error"},
+		{cakelog.LevelError + 4, "error"},
+	}
+
+	for _, test := range tests {
+		logger := new(mockLogger)
+
+		cakelog.Log(context.Background(), logger, test.level, "message", nil)
+
+		if len(logger.calls) != 1 {
+			t.Fatalf("expected 1 call for level %s, got %d", test.level, len(logger.calls))
+		}
+
+		if logger.calls[0].method != test.expected {
+			t.Errorf(
+				"expected level %s to be sent to %s, got %s",
+				test.level,
+				test.expected,
+				logger.calls[0].method,
+			)
+		}
+	}
+}
+
+func TestLog_FallbackErrorArgs(t *testing.T) {
+	t.Parallel()
+
+	logger := new(mockLogger)
+	err := errors.New("warn error")
+
+	cakelog.Log(context.Background(), logger, cakelog.LevelWarn, "warn message", err, "warn", 42)
+
+	call := logger.calls[0]
+
+	if call.msg != "warn message" {
+		t.Errorf("expected message 'warn message', got '%s'", call.msg)
+	}
+
+	if len(call.args) != 4 {
+		t.Fatalf("expected 4 arguments, got %d", len(call.args))
+	}
+
+	if call.args[2] != cakelog.ErrorKey || call.args[3] != err {
+		t.Errorf("expected error under key %q, got %v", cakelog.ErrorKey, call.args[2:])
+	}
+}
+
+func TestLog_FallbackErrorMessage(t *testing.T) {
+	t.Parallel()
+
+	err := errors.New("connection refused")
+
+	tests := []struct {
+		msg      string
+		err      error
+		expected string
+	}{
+		{"", err, "connection refused"},
+		{"connection refused", err, "connection refused"},
+		{"query failed", err, "query failed: connection refused"},
+		{"query failed", nil, "query failed"},
+	}
+
+	for _, test := range tests {
+		logger := new(mockLogger)
+
+		cakelog.Log(context.Background(), logger, cakelog.LevelError, test.msg, test.err)
+
+		got := logger.calls[0].err
+
+		if got == nil || got.Error() != test.expected {
+			t.Errorf("expected error %q, got %v", test.expected, got)
+		}
+
+		if test.err != nil && !errors.Is(got, test.err) {
+			t.Errorf("expected error to wrap %v, got %v", test.err, got)
+		}
+	}
+
+	logger := new(mockLogger)
+
+	cakelog.Log(context.Background(), logger, cakelog.LevelError, "", nil)
+
+	if logger.calls[0].err != nil {
+		t.Errorf("expected nil error, got %v", logger.calls[0].err)
+	}
+}
+
+func TestLog_FallbackTypedNilError(t *testing.T) {
+	t.Parallel()
+
+	var typedNil *statusError
+
+	logger := new(mockLogger)
+
+	cakelog.Log(context.Background(), logger, cakelog.LevelError, "save failed", typedNil)
+	cakelog.Log(context.Background(), logger, cakelog.LevelWarn, "save retried", typedNil, "attempt", 2)
+
+	if len(logger.calls) != 2 {
+		t.Fatalf("expected 2 calls, got %d", len(logger.calls))
+	}
+
+	if err := logger.calls[0].err; err == nil || err.Error() != "save failed" {
+		t.Errorf("expected the message to become the error, got %v", err)
+	}
+
+	if args := logger.calls[1].args; len(args) != 2 {
+		t.Errorf("expected the typed nil error not to be added to the arguments, got %v", args)
+	}
+
+	handler := cakelog.NewHandlerLogger(cakelog.NewLoggerHandler(logger))
+
+	handler.Log(context.Background(), cakelog.LevelError, "", typedNil)
+
+	if len(logger.calls) != 3 || logger.calls[2].err != nil {
+		t.Errorf("expected a nil error to be forwarded, got %v", logger.calls[2:])
+	}
+}
+
+type mockEnabler struct {
+	mockLogger
+
+	minLevel cakelog.Level
+}
+
+func (ml *mockEnabler) Enabled(_ context.Context, level cakelog.Level) bool {
+	return level >= ml.minLevel
+}
+
+var _ cakelog.Enabler = (*mockEnabler)(nil)
+
+func TestEnabled(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	if !cakelog.Enabled(ctx, new(mockLogger), cakelog.LevelDebug-4) {
+		t.Error("expected a logger without Enabler to be enabled at every level")
+	}
+
+	if cakelog.Enabled(ctx, cakelog.NewNopLogger(), cakelog.LevelError) {
+		t.Error("expected NopLogger to be disabled")
+	}
+
+	logger := &mockEnabler{minLevel: cakelog.LevelWarn}
+
+	if cakelog.Enabled(ctx, logger, cakelog.LevelInfo) {
+		t.Error("expected info to be disabled")
+	}
+
+	if !cakelog.Enabled(ctx, logger, cakelog.LevelWarn) {
+		t.Error("expected warn to be enabled")
+	}
+
+	if cakelog.Enabled(ctx, cakelog.WithGroup(cakelog.With(logger, "a", 1), "g"), cakelog.LevelInfo) {
+		t.Error("expected derived loggers to forward Enabled")
+	}
 }
@@ -0,0 +1,21 @@
+package cakelog
+
+// Is a function that decorates a logger, typically by wrapping it in a decorator.
+type Middleware func(Logger) Logger
+
+// Wraps the base logger in the given middlewares and returns the outermost logger.
+// The first middleware is the outermost one, so it sees every message first,
+// and the last middleware wraps the base logger directly:
+// Chain(base, a, b) is the same as a(b(base)).
+// Nil middlewares are skipped.
+func Chain(base Logger, mws ...Middleware) Logger {
+	logger := base
+
+	for idx := len(mws) - 1; idx >= 0; idx-- {
+		if mws[idx] != nil {
+			logger = mws[idx](logger)
+		}
+	}
+
+	return logger
+}
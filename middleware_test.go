@@ -0,0 +1,49 @@
+package cakelog_test
+
+import (
+	"testing"
+
+	"github.com/yuppyweb/cakelog"
+)
+
+func TestChain(t *testing.T) {
+	t.Parallel()
+
+	base := new(mockLogger)
+
+	var order []string
+
+	middleware := func(name string) cakelog.Middleware {
+		return func(logger cakelog.Logger) cakelog.Logger {
+			order = append(order, name)
+
+			return &mockUnwrapLogger{inner: logger}
+		}
+	}
+
+	logger := cakelog.Chain(base, middleware("outer"), nil, middleware("inner"))
+
+	if len(order) != 2 || order[0] != "inner" || order[1] != "outer" {
+		t.Errorf("expected the middlewares to be applied from the last one, got %v", order)
+	}
+
+	outer, ok := logger.(*mockUnwrapLogger)
+	if !ok {
+		t.Fatalf("expected the outermost logger to be returned, got %T", logger)
+	}
+
+	inner, ok := outer.Unwrap().(*mockUnwrapLogger)
+	if !ok || inner.Unwrap() != base {
+		t.Errorf("expected the last middleware to wrap the base logger, got %v", outer.Unwrap())
+	}
+}
+
+func TestChain_NoMiddlewares(t *testing.T) {
+	t.Parallel()
+
+	base := new(mockLogger)
+
+	if cakelog.Chain(base) != base {
+		t.Error("expected the base logger to be returned unchanged")
+	}
+}
@@ -0,0 +1,145 @@
+package cakelog
+
+import (
+	"context"
+)
+
+// Is the key of the attribute under which a named logger records its name.
+const LoggerNameKey = "logger"
+
+// Is the context key type under which a named logger stores its name in the context of its records.
+// This is unexported to prevent collisions with other context keys.
+type loggerNameContextKey struct{}
+
+// Returns a logger that records the given name under LoggerNameKey in every message sent through it.
+// Names form a dotted hierarchy: naming a logger whose chain already contains a named logger
+// appends the name to the existing one, so Named(Named(logger, "db"), "pool") is named "db.pool".
+// An empty name returns the logger unchanged.
+//
+// The name is also available to the loggers below it through LoggerName, for the context of its records
+// and of its Enabled checks, which lets decorators such as decorator.NamedLevelLogger filter by name.
+func Named(logger Logger, name string) Logger {
+	if name == "" {
+		return logger
+	}
+
+	if parent, ok := Find[*namedLogger](logger); ok {
+		name = parent.name + "." + name
+	}
+
+	if nl, ok := logger.(*namedLogger); ok {
+		logger = nl.logger
+	}
+
+	return newNamedLogger(logger, name)
+}
+
+// Returns the name of the named logger through which a record or an Enabled check is passing,
+// or an empty string if the context does not come from a named logger.
+func LoggerName(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+
+	name, _ := ctx.Value(loggerNameContextKey{}).(string)
+
+	return name
+}
+
+// Is a logger that records its name in every message.
+type namedLogger struct {
+	// Provides the logging methods, which pass every message to Handle as a Record.
+	*HandlerLogger
+
+	// The underlying logger to which log messages will be forwarded.
+	logger Logger
+
+	// The full dotted name of the logger.
+	name string
+}
+
+// Helper function to create a namedLogger whose logging methods pass records to its Handle method.
+func newNamedLogger(logger Logger, name string) *namedLogger {
+	nl := &namedLogger{
+		logger: logger,
+		name:   name,
+	}
+	nl.HandlerLogger = NewHandlerLogger(nl)
+
+	return nl
+}
+
+// Records the name as the first attribute of the record and in its context,
+// and writes the record to the underlying logger. A record that already passed through
+// a named logger keeps the name it has, which is the more specific one.
+func (nl *namedLogger) Handle(record Record) {
+	if LoggerName(record.Context) == "" {
+		record.Context = nl.withName(record.Context)
+		record.Attrs = append([]Field{{Key: LoggerNameKey, Value: nl.name}}, record.Attrs...)
+	}
+
+	Handle(nl.logger, record)
+}
+
+// Reports whether the underlying logger would write a message at the given level for this name.
+func (nl *namedLogger) Enabled(ctx context.Context, level Level) bool {
+	if LoggerName(ctx) == "" {
+		ctx = nl.withName(ctx)
+	}
+
+	return Enabled(ctx, nl.logger, level)
+}
+
+// Flushes the buffered messages of the underlying logger.
+func (nl *namedLogger) Sync(ctx context.Context) error {
+	return Sync(ctx, nl.logger)
+}
+
+// Releases the resources of the underlying logger.
+func (nl *namedLogger) Close(ctx context.Context) error {
+	return Close(ctx, nl.logger)
+}
+
+// Returns the underlying logger.
+func (nl *namedLogger) Unwrap() Logger {
+	return nl.logger
+}
+
+// Returns a logger with the same name that binds the given arguments to the underlying logger with With.
+// Groups are not delegated, so that WithGroup nests the arguments but not the name.
+func (nl *namedLogger) With(args ...any) Logger {
+	if len(args) == 0 {
+		return nl
+	}
+
+	return newNamedLogger(With(nl.logger, args...), nl.name)
+}
+
+// Helper method to store the name in the context, which may be nil.
+func (nl *namedLogger) withName(ctx context.Context) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	return context.WithValue(ctx, loggerNameContextKey{}, nl.name)
+}
+
+var (
+	// Ensures that namedLogger implements the Handler interface.
+	_ Handler = (*namedLogger)(nil)
+
+	// Ensures that namedLogger implements the WithLogger interface.
+	_ WithLogger = (*namedLogger)(nil)
+
+	// Ensures that namedLogger implements the Enabler interface.
+	_ Enabler = (*namedLogger)(nil)
+
+	// Ensures that namedLogger implements the Syncer interface.
+	_ Syncer = (*namedLogger)(nil)
+
+	// Ensures that namedLogger implements the Closer interface.
+	_ Closer = (*namedLogger)(nil)
+
+	// Ensures that namedLogger implements the Unwrapper interface.
+	_ Unwrapper = (*namedLogger)(nil)
+)
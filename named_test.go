@@ -0,0 +1,118 @@
+package cakelog_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/yuppyweb/cakelog"
+)
+
+type mockNameEnabler struct {
+	mockHandler
+
+	names []string
+}
+
+func (mn *mockNameEnabler) Enabled(ctx context.Context, _ cakelog.Level) bool {
+	mn.names = append(mn.names, cakelog.LoggerName(ctx))
+
+	return true
+}
+
+var _ cakelog.Enabler = (*mockNameEnabler)(nil)
+
+func TestNamed(t *testing.T) {
+	t.Parallel()
+
+	handler := new(mockHandler)
+	logger := cakelog.Named(cakelog.NewHandlerLogger(handler), "db.pool")
+
+	logger.Warn(context.Background(), "slow query", "ms", 120)
+
+	if len(handler.records) != 1 {
+		t.Fatalf("expected 1 record, got %d", len(handler.records))
+	}
+
+	record := handler.records[0]
+	expected := []cakelog.Field{{Key: cakelog.LoggerNameKey, Value: "db.pool"}, {Key: "ms", Value: 120}}
+
+	if !reflect.DeepEqual(record.Attrs, expected) {
+		t.Errorf("expected the name before the arguments, got %v", record.Attrs)
+	}
+
+	if name := cakelog.LoggerName(record.Context); name != "db.pool" {
+		t.Errorf("expected the name in the context of the record, got %q", name)
+	}
+
+	if record.PC == 0 {
+		t.Error("expected the program counter of the call")
+	}
+}
+
+func TestNamed_Hierarchy(t *testing.T) {
+	t.Parallel()
+
+	handler := new(mockHandler)
+	base := cakelog.Named(cakelog.NewHandlerLogger(handler), "db")
+	child := cakelog.Named(cakelog.With(base, "shard", 2), "pool")
+
+	child.Warn(context.Background(), "exhausted")
+
+	expected := []cakelog.Field{
+		{Key: "shard", Value: 2},
+		{Key: cakelog.LoggerNameKey, Value: "db.pool"},
+	}
+
+	if len(handler.records) != 1 || !reflect.DeepEqual(handler.records[0].Attrs, expected) {
+		t.Fatalf("expected a single name attribute with the full name, got %v", handler.records)
+	}
+
+	nested := cakelog.Named(cakelog.Named(child, "conn"), "")
+
+	nested.Warn(context.Background(), "closed")
+
+	if name := cakelog.LoggerName(handler.records[1].Context); name != "db.pool.conn" {
+		t.Errorf("expected db.pool.conn, got %q", name)
+	}
+
+	if _, ok := cakelog.Named(base, "pool").(cakelog.Unwrapper); !ok {
+		t.Error("expected a named logger to be unwrappable")
+	}
+}
+
+func TestNamed_WithGroup(t *testing.T) {
+	t.Parallel()
+
+	handler := new(mockHandler)
+	logger := cakelog.WithGroup(cakelog.Named(cakelog.NewHandlerLogger(handler), "http"), "request")
+
+	logger.Warn(context.Background(), "slow", "path", "/")
+
+	expected := []cakelog.Field{
+		{Key: cakelog.LoggerNameKey, Value: "http"},
+		{Key: "request", Value: []cakelog.Field{{Key: "path", Value: "/"}}},
+	}
+
+	if len(handler.records) != 1 || !reflect.DeepEqual(handler.records[0].Attrs, expected) {
+		t.Errorf("expected the name outside of the group, got %v", handler.records)
+	}
+}
+
+func TestNamed_Enabled(t *testing.T) {
+	t.Parallel()
+
+	handler := new(mockNameEnabler)
+	logger := cakelog.Named(cakelog.NewHandlerLogger(handler), "cache")
+
+	cakelog.Enabled(context.Background(), logger, cakelog.LevelInfo)
+	cakelog.Enabled(context.Background(), cakelog.NewHandlerLogger(handler), cakelog.LevelInfo)
+
+	if !reflect.DeepEqual(handler.names, []string{"cache", ""}) {
+		t.Errorf("expected the name in the context of named checks only, got %v", handler.names)
+	}
+
+	if cakelog.LoggerName(nil) != "" {
+		t.Error("expected no name for a nil context")
+	}
+}
@@ -0,0 +1,71 @@
+package cakelog
+
+import (
+	"context"
+	"slices"
+	"time"
+)
+
+// Is a single log message with everything known about it at the time it was logged.
+// Decorators written as a Handler receive a Record, may add attributes to it, and pass it on.
+type Record struct {
+	// The time at which the message was logged.
+	Time time.Time
+
+	// The level of the message.
+	Level Level
+
+	// The text of the message, which may be empty for an error.
+	Message string
+
+	// The error attached to the message, if any.
+	Err error
+
+	// The normalized arguments of the message.
+	Attrs []Field
+
+	// The program counter of the call that logged the message, or zero if it is unknown.
+	PC uintptr
+
+	// The context passed to the call that logged the message.
+	Context context.Context //nolint:containedctx // A record carries the context of its log call.
+}
+
+// Creates a new Record logged now with the given context, level, message, error and normalized arguments.
+// The program counter is left zero.
+func NewRecord(ctx context.Context, level Level, msg string, err error, args ...any) Record {
+	return Record{
+		Time:    time.Now(),
+		Level:   level,
+		Message: msg,
+		Err:     err,
+		Attrs:   Normalize(args...),
+		Context: ctx,
+	}
+}
+
+// Appends the given arguments to the attributes of the record after normalizing them.
+// The attributes are copied before appending, so records sharing attributes are not affected.
+func (r *Record) Add(args ...any) {
+	if len(args) == 0 {
+		return
+	}
+
+	r.Attrs = append(slices.Clip(r.Attrs), Normalize(args...)...)
+}
+
+// Returns the attributes of the record as alternating keys and values,
+// in the form accepted by the methods of the Logger interface.
+func (r Record) Args() []any {
+	if len(r.Attrs) == 0 {
+		return nil
+	}
+
+	args := make([]any, 0, len(r.Attrs)*2)
+
+	for _, field := range r.Attrs {
+		args = append(args, field.Key, field.Value)
+	}
+
+	return args
+}
@@ -0,0 +1,70 @@
+package cakelog_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/yuppyweb/cakelog"
+)
+
+func TestNewRecord(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	expectedErr := errors.New("failed")
+
+	record := cakelog.NewRecord(ctx, cakelog.LevelWarn, "message", expectedErr, "user", 42, map[string]any{"id": 1})
+
+	if record.Time.IsZero() {
+		t.Error("expected the record time to be set")
+	}
+
+	if record.Level != cakelog.LevelWarn || record.Message != "message" || record.Err != expectedErr {
+		t.Errorf("unexpected record: %+v", record)
+	}
+
+	if record.Context != ctx || record.PC != 0 {
+		t.Errorf("unexpected record context or program counter: %+v", record)
+	}
+
+	expected := []cakelog.Field{cakelog.NewField("user", 42), cakelog.NewField("id", 1)}
+
+	if !reflect.DeepEqual(record.Attrs, expected) {
+		t.Errorf("expected attributes %v, got %v", expected, record.Attrs)
+	}
+}
+
+func TestRecord_Add(t *testing.T) {
+	t.Parallel()
+
+	record := cakelog.NewRecord(context.Background(), cakelog.LevelInfo, "message", nil, "a", 1, "b", 2)
+	record.Attrs = record.Attrs[:1]
+
+	first := record
+	first.Add("c", 3)
+
+	second := record
+	second.Add("d", 4)
+
+	if !reflect.DeepEqual(first.Args(), []any{"a", 1, "c", 3}) {
+		t.Errorf("unexpected arguments of the first record: %v", first.Args())
+	}
+
+	if !reflect.DeepEqual(second.Args(), []any{"a", 1, "d", 4}) {
+		t.Errorf("unexpected arguments of the second record: %v", second.Args())
+	}
+
+	if len(record.Attrs) != 1 {
+		t.Errorf("expected the original record to be unchanged, got %v", record.Attrs)
+	}
+}
+
+func TestRecord_ArgsEmpty(t *testing.T) {
+	t.Parallel()
+
+	if args := (cakelog.Record{}).Args(); args != nil {
+		t.Errorf("expected no arguments, got %v", args)
+	}
+}
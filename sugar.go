@@ -0,0 +1,95 @@
+package cakelog
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+)
+
+// Is the default key of the attribute under which Sugar keeps the format string of a message.
+const DefaultFormatKey = "format"
+
+// Sugar is a wrapper over a Logger with printf-style logging methods.
+// Messages are formatted only if the logger is enabled for their level,
+// and the format string is kept as a separate attribute, so backends can group messages by it.
+// The messages are passed on as a Record that points at the caller of the Sugar method.
+type Sugar struct {
+	// The logger to which messages are written. Its methods can be used directly on the Sugar.
+	Logger
+
+	// The key of the attribute under which the format string is kept. An empty key omits the attribute.
+	FormatKey string
+}
+
+// Creates a new Sugar over the provided logger.
+func NewSugar(logger Logger) *Sugar {
+	return &Sugar{
+		Logger:    logger,
+		FormatKey: DefaultFormatKey,
+	}
+}
+
+// Formats and writes a debug message.
+func (s *Sugar) Debugf(ctx context.Context, format string, args ...any) {
+	s.logf(ctx, LevelDebug, false, format, args)
+}
+
+// Formats and writes an info message.
+func (s *Sugar) Infof(ctx context.Context, format string, args ...any) {
+	s.logf(ctx, LevelInfo, false, format, args)
+}
+
+// Formats and writes a warning message.
+func (s *Sugar) Warnf(ctx context.Context, format string, args ...any) {
+	s.logf(ctx, LevelWarn, false, format, args)
+}
+
+// Writes an error message with an error created by fmt.Errorf, so the %w verb wraps errors.
+func (s *Sugar) Errorf(ctx context.Context, format string, args ...any) {
+	s.logf(ctx, LevelError, true, format, args)
+}
+
+// Formats and writes a message at the given level.
+func (s *Sugar) Logf(ctx context.Context, level Level, format string, args ...any) {
+	s.logf(ctx, level, false, format, args)
+}
+
+// Returns the logger to which messages are written.
+func (s *Sugar) Unwrap() Logger {
+	return s.Logger
+}
+
+// Helper method to format a message, if its level is enabled, and write it as a record pointing at the caller.
+func (s *Sugar) logf(ctx context.Context, level Level, asError bool, format string, args []any) {
+	if !Enabled(ctx, s.Logger, level) {
+		return
+	}
+
+	var record Record
+
+	if asError {
+		record = NewRecord(ctx, level, "", fmt.Errorf(format, args...)) //nolint:err113 // The format is the caller's.
+	} else {
+		record = NewRecord(ctx, level, fmt.Sprintf(format, args...), nil)
+	}
+
+	if s.FormatKey != "" {
+		record.Add(s.FormatKey, format)
+	}
+
+	var pcs [1]uintptr
+
+	// Skips runtime.Callers, this method and the Sugar method.
+	runtime.Callers(3, pcs[:])
+	record.PC = pcs[0]
+
+	Handle(s.Logger, record)
+}
+
+var (
+	// Ensures that Sugar implements the Logger interface.
+	_ Logger = (*Sugar)(nil)
+
+	// Ensures that Sugar implements the Unwrapper interface.
+	_ Unwrapper = (*Sugar)(nil)
+)
@@ -0,0 +1,144 @@
+package cakelog_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/yuppyweb/cakelog"
+)
+
+type countingStringer struct {
+	calls int
+}
+
+func (cs *countingStringer) String() string {
+	cs.calls++
+
+	return "value"
+}
+
+func TestSugar(t *testing.T) {
+	t.Parallel()
+
+	handler := new(mockHandler)
+	sugar := cakelog.NewSugar(cakelog.NewHandlerLogger(handler))
+
+	sugar.Warnf(context.Background(), "user %d not found", 42)
+
+	if len(handler.records) != 1 {
+		t.Fatalf("expected 1 record, got %d", len(handler.records))
+	}
+
+	record := handler.records[0]
+
+	if record.Level != cakelog.LevelWarn || record.Message != "user 42 not found" {
+		t.Errorf("unexpected record: %v %q", record.Level, record.Message)
+	}
+
+	expected := []cakelog.Field{{Key: cakelog.DefaultFormatKey, Value: "user %d not found"}}
+
+	if !reflect.DeepEqual(record.Attrs, expected) {
+		t.Errorf("unexpected attributes:\nGot:  %v\nWant: %v", record.Attrs, expected)
+	}
+
+	frame, _ := runtime.CallersFrames([]uintptr{record.PC}).Next()
+
+	if !strings.HasSuffix(frame.Function, "TestSugar") {
+		t.Errorf("expected the record to point at TestSugar, got %q", frame.Function)
+	}
+}
+
+func TestSugar_Disabled(t *testing.T) {
+	t.Parallel()
+
+	handler := new(mockHandler)
+	sugar := cakelog.NewSugar(cakelog.NewHandlerLogger(handler))
+	stringer := new(countingStringer)
+
+	sugar.Debugf(context.Background(), "debug %s", stringer)
+	sugar.Infof(context.Background(), "info %s", stringer)
+
+	if len(handler.records) != 0 {
+		t.Errorf("expected no records, got %d", len(handler.records))
+	}
+
+	if stringer.calls != 0 {
+		t.Errorf("expected disabled messages not to be formatted, got %d calls", stringer.calls)
+	}
+}
+
+func TestSugar_Errorf(t *testing.T) {
+	t.Parallel()
+
+	handler := new(mockHandler)
+	sugar := cakelog.NewSugar(cakelog.NewHandlerLogger(handler))
+	expectedErr := errors.New("connection refused")
+
+	sugar.Errorf(context.Background(), "dial %s: %w", "db", expectedErr)
+
+	record := handler.records[0]
+
+	if record.Level != cakelog.LevelError || record.Message != "" {
+		t.Errorf("unexpected record: %v %q", record.Level, record.Message)
+	}
+
+	if !errors.Is(record.Err, expectedErr) || record.Err.Error() != "dial db: connection refused" {
+		t.Errorf("expected the error to wrap %v, got %v", expectedErr, record.Err)
+	}
+
+	frame, _ := runtime.CallersFrames([]uintptr{record.PC}).Next()
+
+	if !strings.HasSuffix(frame.Function, "TestSugar_Errorf") {
+		t.Errorf("expected the record to point at TestSugar_Errorf, got %q", frame.Function)
+	}
+}
+
+func TestSugar_WithoutFormatKey(t *testing.T) {
+	t.Parallel()
+
+	handler := new(mockHandler)
+	sugar := cakelog.NewSugar(cakelog.NewHandlerLogger(handler))
+	sugar.FormatKey = ""
+
+	sugar.Logf(context.Background(), cakelog.LevelFatal, "exit %d", 1)
+
+	if record := handler.records[0]; record.Message != "exit 1" || len(record.Attrs) != 0 {
+		t.Errorf("unexpected record: %q %v", record.Message, record.Attrs)
+	}
+}
+
+func TestSugar_Fallback(t *testing.T) {
+	t.Parallel()
+
+	logger := new(mockLogger)
+	sugar := cakelog.NewSugar(cakelog.With(logger, "service", "api"))
+
+	sugar.Infof(context.Background(), "took %dms", 15)
+	sugar.Debug(context.Background(), "plain message")
+
+	if len(logger.calls) != 2 {
+		t.Fatalf("expected 2 calls, got %d", len(logger.calls))
+	}
+
+	if logger.calls[0].method != "info" || logger.calls[0].msg != "took 15ms" {
+		t.Errorf("unexpected call: %s %q", logger.calls[0].method, logger.calls[0].msg)
+	}
+
+	expected := []any{"service", "api", cakelog.DefaultFormatKey, "took %dms"}
+
+	if !reflect.DeepEqual(logger.calls[0].args, expected) {
+		t.Errorf("unexpected arguments:\nGot:  %v\nWant: %v", logger.calls[0].args, expected)
+	}
+
+	if logger.calls[1].method != "debug" {
+		t.Errorf("expected the plain method to be promoted, got %s", logger.calls[1].method)
+	}
+
+	if sugar.Unwrap() == nil {
+		t.Error("expected Unwrap to return the logger")
+	}
+}
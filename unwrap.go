@@ -0,0 +1,61 @@
+package cakelog
+
+// Is an optional interface for decorators that expose the logger they wrap.
+type Unwrapper interface {
+	// Returns the logger to which messages are forwarded.
+	Unwrap() Logger
+}
+
+// Is an optional interface for loggers that forward messages to several loggers.
+type MultiUnwrapper interface {
+	// Returns the loggers to which messages are forwarded.
+	Unwrap() []Logger
+}
+
+// Visits the provided logger and every logger it wraps, depth first and in forwarding order.
+// Loggers are unwrapped through the Unwrapper and MultiUnwrapper interfaces.
+// The walk stops as soon as the function returns false.
+func Walk(logger Logger, fn func(Logger) bool) {
+	walk(logger, fn)
+}
+
+// Returns the first logger in the chain of the provided logger that has the type T, as reported by Walk.
+// T may be a concrete logger type or an interface such as decorator.ContextEnricher.
+func Find[T any](logger Logger) (T, bool) {
+	var (
+		found T
+		ok    bool
+	)
+
+	Walk(logger, func(l Logger) bool {
+		found, ok = l.(T)
+
+		return !ok
+	})
+
+	return found, ok
+}
+
+// Helper function to walk the chain of loggers, reporting whether the walk should continue.
+func walk(logger Logger, fn func(Logger) bool) bool {
+	if logger == nil {
+		return true
+	}
+
+	if !fn(logger) {
+		return false
+	}
+
+	switch wrapper := logger.(type) {
+	case Unwrapper:
+		return walk(wrapper.Unwrap(), fn)
+	case MultiUnwrapper:
+		for _, inner := range wrapper.Unwrap() {
+			if !walk(inner, fn) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
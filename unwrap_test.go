@@ -0,0 +1,95 @@
+package cakelog_test
+
+import (
+	"testing"
+
+	"github.com/yuppyweb/cakelog"
+)
+
+type mockUnwrapLogger struct {
+	mockLogger
+
+	inner cakelog.Logger
+}
+
+func (ml *mockUnwrapLogger) Unwrap() cakelog.Logger {
+	return ml.inner
+}
+
+type mockMultiLogger struct {
+	mockLogger
+
+	sinks []cakelog.Logger
+}
+
+func (ml *mockMultiLogger) Unwrap() []cakelog.Logger {
+	return ml.sinks
+}
+
+var (
+	_ cakelog.Unwrapper      = (*mockUnwrapLogger)(nil)
+	_ cakelog.MultiUnwrapper = (*mockMultiLogger)(nil)
+)
+
+func TestWalk(t *testing.T) {
+	t.Parallel()
+
+	first := new(mockLogger)
+	second := new(mockLevelLogger)
+	multi := &mockMultiLogger{sinks: []cakelog.Logger{first, second}}
+	top := &mockUnwrapLogger{inner: cakelog.With(multi, "service", "api")}
+
+	var visited []cakelog.Logger
+
+	cakelog.Walk(top, func(logger cakelog.Logger) bool {
+		visited = append(visited, logger)
+
+		return true
+	})
+
+	if len(visited) != 5 {
+		t.Fatalf("expected 5 loggers to be visited, got %d", len(visited))
+	}
+
+	if visited[0] != top || visited[2] != multi || visited[3] != first || visited[4] != second {
+		t.Errorf("unexpected walk order: %v", visited)
+	}
+}
+
+func TestWalk_Stop(t *testing.T) {
+	t.Parallel()
+
+	multi := &mockMultiLogger{sinks: []cakelog.Logger{new(mockLogger), new(mockLogger)}}
+
+	visits := 0
+
+	cakelog.Walk(multi, func(cakelog.Logger) bool {
+		visits++
+
+		return visits < 2
+	})
+
+	if visits != 2 {
+		t.Errorf("expected the walk to stop after 2 loggers, got %d", visits)
+	}
+}
+
+func TestFind(t *testing.T) {
+	t.Parallel()
+
+	base := new(mockLevelLogger)
+	top := &mockUnwrapLogger{inner: cakelog.WithGroup(base, "http")}
+
+	found, ok := cakelog.Find[*mockLevelLogger](top)
+	if !ok || found != base {
+		t.Errorf("expected to find the base logger, got %v, %t", found, ok)
+	}
+
+	if _, ok := cakelog.Find[cakelog.LevelLogger](top); !ok {
+		t.Error("expected to find a logger by interface")
+	}
+
+	if _, ok := cakelog.Find[*mockMultiLogger](top); ok {
+		t.Error("expected a missing logger not to be found")
+	}
+}
@@ -0,0 +1,247 @@
+package cakelog
+
+import (
+	"context"
+	"slices"
+)
+
+// Is an optional interface for loggers that can natively create a derived logger with pre-bound arguments.
+type WithLogger interface {
+	// Returns a logger that includes the given arguments in every message.
+	With(args ...any) Logger
+}
+
+// Is an optional interface for loggers that can natively create a derived logger
+// which nests the arguments of every message in a group.
+type GroupLogger interface {
+	// Returns a logger that nests the arguments of every message under the given name.
+	WithGroup(name string) Logger
+}
+
+// Returns a logger that includes the given arguments in every message sent through it.
+// If the logger implements WithLogger, the native implementation is used,
+// otherwise the arguments are normalized once and prepended to the arguments of every call.
+// Lazy values among the arguments are computed again for every record, see RenewLazy.
+func With(logger Logger, args ...any) Logger {
+	if len(args) == 0 {
+		return logger
+	}
+
+	if wl, ok := logger.(WithLogger); ok {
+		return wl.With(args...)
+	}
+
+	return &withLogger{
+		logger: logger,
+		fields: Normalize(args...),
+	}
+}
+
+// Returns a logger that nests the arguments of every message sent through it under the given name.
+// If the logger implements GroupLogger, the native implementation is used,
+// otherwise the arguments of every call are wrapped in a group field.
+// An empty name returns the logger unchanged.
+func WithGroup(logger Logger, name string) Logger {
+	if name == "" {
+		return logger
+	}
+
+	if gl, ok := logger.(GroupLogger); ok {
+		return gl.WithGroup(name)
+	}
+
+	return &groupLogger{
+		logger: logger,
+		name:   name,
+	}
+}
+
+// Is a logger that prepends pre-bound fields to the arguments of every message.
+type withLogger struct {
+	// The underlying logger to which log messages will be forwarded.
+	logger Logger
+
+	// The normalized fields bound to the logger. Their lazy values are never resolved themselves,
+	// only renewed for every record.
+	fields []Field
+}
+
+// Sends a debug message to the underlying logger with the bound fields.
+func (wl *withLogger) Debug(ctx context.Context, msg string, args ...any) {
+	wl.Log(ctx, LevelDebug, msg, nil, args...)
+}
+
+// Sends an info message to the underlying logger with the bound fields.
+func (wl *withLogger) Info(ctx context.Context, msg string, args ...any) {
+	wl.Log(ctx, LevelInfo, msg, nil, args...)
+}
+
+// Sends a warning message to the underlying logger with the bound fields.
+func (wl *withLogger) Warn(ctx context.Context, msg string, args ...any) {
+	wl.Log(ctx, LevelWarn, msg, nil, args...)
+}
+
+// Sends an error message to the underlying logger with the bound fields.
+func (wl *withLogger) Error(ctx context.Context, err error, args ...any) {
+	wl.Log(ctx, LevelError, "", err, args...)
+}
+
+// Sends a message at the given level to the underlying logger with the bound fields.
+func (wl *withLogger) Log(ctx context.Context, level Level, msg string, err error, args ...any) {
+	Log(ctx, wl.logger, level, msg, err, append([]any{RenewLazy(wl.fields)}, args...)...)
+}
+
+// Reports whether the underlying logger would write a message at the given level.
+func (wl *withLogger) Enabled(ctx context.Context, level Level) bool {
+	return Enabled(ctx, wl.logger, level)
+}
+
+// Flushes the buffered messages of the underlying logger.
+func (wl *withLogger) Sync(ctx context.Context) error {
+	return Sync(ctx, wl.logger)
+}
+
+// Releases the resources of the underlying logger.
+func (wl *withLogger) Close(ctx context.Context) error {
+	return Close(ctx, wl.logger)
+}
+
+// Prepends the bound fields to the attributes of the record and writes it to the underlying logger.
+func (wl *withLogger) Handle(record Record) {
+	record.Attrs = append(slices.Clip(RenewLazy(wl.fields)), record.Attrs...)
+
+	Handle(wl.logger, record)
+}
+
+// Returns the underlying logger.
+func (wl *withLogger) Unwrap() Logger {
+	return wl.logger
+}
+
+// Returns a logger with the given arguments bound after the already bound fields.
+func (wl *withLogger) With(args ...any) Logger {
+	if len(args) == 0 {
+		return wl
+	}
+
+	return &withLogger{
+		logger: wl.logger,
+		fields: append(slices.Clip(wl.fields), Normalize(args...)...),
+	}
+}
+
+// Is a logger that nests the arguments of every message in a group.
+type groupLogger struct {
+	// The underlying logger to which log messages will be forwarded.
+	logger Logger
+
+	// The name of the group.
+	name string
+}
+
+// Sends a debug message to the underlying logger with the arguments nested in the group.
+func (gl *groupLogger) Debug(ctx context.Context, msg string, args ...any) {
+	gl.Log(ctx, LevelDebug, msg, nil, args...)
+}
+
+// Sends an info message to the underlying logger with the arguments nested in the group.
+func (gl *groupLogger) Info(ctx context.Context, msg string, args ...any) {
+	gl.Log(ctx, LevelInfo, msg, nil, args...)
+}
+
+// Sends a warning message to the underlying logger with the arguments nested in the group.
+func (gl *groupLogger) Warn(ctx context.Context, msg string, args ...any) {
+	gl.Log(ctx, LevelWarn, msg, nil, args...)
+}
+
+// Sends an error message to the underlying logger with the arguments nested in the group.
+func (gl *groupLogger) Error(ctx context.Context, err error, args ...any) {
+	gl.Log(ctx, LevelError, "", err, args...)
+}
+
+// Sends a message at the given level to the underlying logger with the arguments nested in the group.
+// Messages without arguments are forwarded without an empty group.
+func (gl *groupLogger) Log(ctx context.Context, level Level, msg string, err error, args ...any) {
+	if len(args) > 0 {
+		args = []any{NewGroup(gl.name, args...)}
+	}
+
+	Log(ctx, gl.logger, level, msg, err, args...)
+}
+
+// Reports whether the underlying logger would write a message at the given level.
+func (gl *groupLogger) Enabled(ctx context.Context, level Level) bool {
+	return Enabled(ctx, gl.logger, level)
+}
+
+// Flushes the buffered messages of the underlying logger.
+func (gl *groupLogger) Sync(ctx context.Context) error {
+	return Sync(ctx, gl.logger)
+}
+
+// Releases the resources of the underlying logger.
+func (gl *groupLogger) Close(ctx context.Context) error {
+	return Close(ctx, gl.logger)
+}
+
+// Nests the attributes of the record in the group and writes it to the underlying logger.
+// Records without attributes are forwarded without an empty group.
+func (gl *groupLogger) Handle(record Record) {
+	if len(record.Attrs) > 0 {
+		record.Attrs = []Field{{Key: gl.name, Value: record.Attrs}}
+	}
+
+	Handle(gl.logger, record)
+}
+
+// Returns the underlying logger.
+func (gl *groupLogger) Unwrap() Logger {
+	return gl.logger
+}
+
+var (
+	// Ensures that withLogger implements the Logger interface.
+	_ Logger = (*withLogger)(nil)
+
+	// Ensures that withLogger implements the LevelLogger interface.
+	_ LevelLogger = (*withLogger)(nil)
+
+	// Ensures that withLogger implements the Handler interface.
+	_ Handler = (*withLogger)(nil)
+
+	// Ensures that withLogger implements the WithLogger interface.
+	_ WithLogger = (*withLogger)(nil)
+
+	// Ensures that withLogger implements the Enabler interface.
+	_ Enabler = (*withLogger)(nil)
+
+	// Ensures that withLogger implements the Syncer interface.
+	_ Syncer = (*withLogger)(nil)
+
+	// Ensures that withLogger implements the Closer interface.
+	_ Closer = (*withLogger)(nil)
+
+	// Ensures that withLogger implements the Unwrapper interface.
+	_ Unwrapper = (*withLogger)(nil)
+
+	// Ensures that groupLogger implements the Logger interface.
+	_ Logger = (*groupLogger)(nil)
+
+	// Ensures that groupLogger implements the LevelLogger interface.
+	_ LevelLogger = (*groupLogger)(nil)
+
+	// Ensures that groupLogger implements the Handler interface.
+	_ Handler = (*groupLogger)(nil)
+
+	// Ensures that groupLogger implements the Enabler interface.
+	_ Enabler = (*groupLogger)(nil)
+
+	// Ensures that groupLogger implements the Syncer interface.
+	_ Syncer = (*groupLogger)(nil)
+
+	// Ensures that groupLogger implements the Closer interface.
+	_ Closer = (*groupLogger)(nil)
+
+	// Ensures that groupLogger implements the Unwrapper interface.
+	_ Unwrapper = (*groupLogger)(nil)
+)
@@ -0,0 +1,189 @@
+package cakelog_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/yuppyweb/cakelog"
+)
+
+type mockWithLogger struct {
+	mockLogger
+
+	withArgs  []any
+	groupName string
+}
+
+func (ml *mockWithLogger) With(args ...any) cakelog.Logger {
+	ml.withArgs = args
+
+	return ml
+}
+
+func (ml *mockWithLogger) WithGroup(name string) cakelog.Logger {
+	ml.groupName = name
+
+	return ml
+}
+
+var (
+	_ cakelog.WithLogger  = (*mockWithLogger)(nil)
+	_ cakelog.GroupLogger = (*mockWithLogger)(nil)
+)
+
+func TestWith_Native(t *testing.T) {
+	t.Parallel()
+
+	logger := new(mockWithLogger)
+
+	derived := cakelog.With(logger, "service", "api")
+
+	if derived != logger {
+		t.Fatalf("expected the native With result, got %T", derived)
+	}
+
+	if !reflect.DeepEqual(logger.withArgs, []any{"service", "api"}) {
+		t.Errorf("unexpected With arguments: %v", logger.withArgs)
+	}
+
+	if cakelog.WithGroup(logger, "http") != logger || logger.groupName != "http" {
+		t.Errorf("expected the native WithGroup to be used, got group %q", logger.groupName)
+	}
+}
+
+func TestWith_NoArguments(t *testing.T) {
+	t.Parallel()
+
+	logger := new(mockLogger)
+
+	if cakelog.With(logger) != logger {
+		t.Error("expected With without arguments to return the logger unchanged")
+	}
+
+	if cakelog.WithGroup(logger, "") != logger {
+		t.Error("expected WithGroup with an empty name to return the logger unchanged")
+	}
+}
+
+func TestWith_Fallback(t *testing.T) {
+	t.Parallel()
+
+	logger := new(mockLogger)
+
+	derived := cakelog.With(cakelog.With(logger, "service", "api"), "version", 2)
+
+	derived.Info(context.Background(), "info message", "user", 42)
+	derived.Error(context.Background(), errors.New("error message"))
+
+	if len(logger.calls) != 2 {
+		t.Fatalf("expected 2 calls, got %d", len(logger.calls))
+	}
+
+	expected := []cakelog.Field{
+		{Key: "service", Value: "api"},
+		{Key: "version", Value: 2},
+		{Key: "user", Value: 42},
+	}
+
+	if got := cakelog.Normalize(logger.calls[0].args...); !reflect.DeepEqual(got, expected) {
+		t.Errorf("unexpected info fields:\nGot:  %v\nWant: %v", got, expected)
+	}
+
+	if got := cakelog.Normalize(logger.calls[1].args...); !reflect.DeepEqual(got, expected[:2]) {
+		t.Errorf("unexpected error fields:\nGot:  %v\nWant: %v", got, expected[:2])
+	}
+
+	if logger.calls[1].err == nil || logger.calls[1].err.Error() != "error message" {
+		t.Errorf("expected error 'error message', got %v", logger.calls[1].err)
+	}
+}
+
+func TestWithGroup_Fallback(t *testing.T) {
+	t.Parallel()
+
+	logger := new(mockLogger)
+
+	derived := cakelog.With(cakelog.WithGroup(cakelog.With(logger, "service", "api"), "http"), "method", "GET")
+
+	derived.Warn(context.Background(), "warn message", "status", 503)
+
+	expected := []cakelog.Field{
+		{Key: "service", Value: "api"},
+		{Key: "http", Value: []cakelog.Field{
+			{Key: "method", Value: "GET"},
+			{Key: "status", Value: 503},
+		}},
+	}
+
+	if got := cakelog.Normalize(logger.calls[0].args...); !reflect.DeepEqual(got, expected) {
+		t.Errorf("unexpected fields:\nGot:  %v\nWant: %v", got, expected)
+	}
+}
+
+func TestWithGroup_FallbackWithoutArguments(t *testing.T) {
+	t.Parallel()
+
+	logger := new(mockLogger)
+
+	cakelog.WithGroup(logger, "http").Debug(context.Background(), "debug message")
+
+	if len(logger.calls[0].args) != 0 {
+		t.Errorf("expected no arguments, got %v", logger.calls[0].args)
+	}
+}
+
+func TestWith_Handler(t *testing.T) {
+	t.Parallel()
+
+	handler := new(mockHandler)
+	derived := cakelog.WithGroup(cakelog.With(cakelog.NewHandlerLogger(handler), "service", "api"), "http")
+
+	record := cakelog.NewRecord(context.Background(), cakelog.LevelWarn, "warn message", nil, "status", 503)
+	record.PC = 42
+
+	cakelog.Handle(derived, record)
+
+	if len(handler.records) != 1 {
+		t.Fatalf("expected 1 record, got %d", len(handler.records))
+	}
+
+	expected := []cakelog.Field{
+		{Key: "service", Value: "api"},
+		{Key: "http", Value: []cakelog.Field{{Key: "status", Value: 503}}},
+	}
+
+	if got := handler.records[0]; !reflect.DeepEqual(got.Attrs, expected) || got.PC != 42 {
+		t.Errorf("unexpected record:\nGot:  %v %d\nWant: %v 42", got.Attrs, got.PC, expected)
+	}
+}
+
+func TestWith_LazyPerRecord(t *testing.T) {
+	t.Parallel()
+
+	handler := new(mockHandler)
+	calls := 0
+	logger := cakelog.With(cakelog.NewHandlerLogger(handler), cakelog.LazyField("seq", func() any {
+		calls++
+
+		return calls
+	}))
+
+	logger.Info(context.Background(), "first")
+	logger.Info(context.Background(), "second")
+
+	if calls != 0 {
+		t.Fatalf("expected the bound lazy value not to be resolved before it is written, got %d calls", calls)
+	}
+
+	for idx, record := range handler.records {
+		if value := cakelog.Resolve(record.Attrs[0].Value); value != idx+1 {
+			t.Errorf("expected a fresh value %d in record %d, got %v", idx+1, idx, value)
+		}
+
+		if value := cakelog.Resolve(record.Attrs[0].Value); value != idx+1 {
+			t.Errorf("expected the value of record %d to be resolved once, got %v", idx, value)
+		}
+	}
+}